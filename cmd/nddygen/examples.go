@@ -0,0 +1,75 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nddygen
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/yndd/ndd-runtime/pkg/logging"
+	"github.com/yndd/ndd-ygen/pkg/generator"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+var (
+	exampleOutputDir string
+)
+
+// examplesCmd represents the examples command
+var examplesCmd = &cobra.Command{
+	Use:   "examples",
+	Short: "generate an example custom resource per resource",
+	Long: `generate an example custom resource per resource that is accepted by the CRD:
+the keys and mandatory leaves are set with placeholders that conform to the yang
+types, the leaves with a default are set to the default, enumerations and identities
+use the first value, lists are only populated when they are mandatory and only the
+first case in name order of a choice is populated.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		zlog := zap.New(zap.UseDevMode(debug), zap.JSONEncoder())
+		log := logging.NewLogrLogger(zlog.WithName("nddgenyang"))
+		log.Debug("generate examples ...")
+
+		opts := []generator.Option{
+			generator.WithHealthStatus(healthState),
+			generator.WithVersion(version),
+			generator.WithAPIGroup(apiGroup),
+			generator.WithPrefix(prefix),
+			generator.WithExampleOutputDir(exampleOutputDir),
+			generator.WithLogging(log),
+			generator.WithDebug(debug),
+		}
+		opts = append(opts, getYangInputOptions(cmd)...)
+		g, err := generator.NewGenerator(opts...)
+		if err != nil {
+			return errors.Wrap(err, errCreateGenerator)
+		}
+		if err := g.Run(); err != nil {
+			return err
+		}
+		return g.RenderExamples()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(examplesCmd)
+	addYangInputFlags(examplesCmd)
+	examplesCmd.Flags().StringVarP(&version, "version", "v", "v1alpha1", "The version of the api to generate")
+	examplesCmd.Flags().StringVarP(&apiGroup, "apiGroup", "g", "srl.ndd.henderiw.be", "The group of the api to generate")
+	examplesCmd.Flags().StringVarP(&prefix, "prefix", "a", "srl", "The prefix that is added to the kubernetes api resource")
+	examplesCmd.Flags().BoolVarP(&healthState, "health-state", "s", false, "The schema needs healthstate")
+	examplesCmd.Flags().StringVarP(&exampleOutputDir, "output-dir", "o", "examples/", "The directory the example custom resources are written to")
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nddygen
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/yndd/ndd-ygen/pkg/generator"
+)
+
+// the yang input flags are shared by the commands that process the yang modules
+var (
+	yangImportDirs       []string
+	yangModuleDirs       []string
+	deviationModules     []string
+	features             []string
+	yangLibrary          string
+	moduleRevisions      []string
+	strict               bool
+	resourceMapInputFile string
+)
+
+// addYangInputFlags adds the flags that select the yang modules and the resource map
+func addYangInputFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVarP(&yangImportDirs, "yang-import-dirs", "i", []string{}, "Comma separated list of dirs to be recursively searched for import modules, or archives (.tar, .tar.gz, .tgz, .zip) with import modules as <archive>[:<glob>].")
+	cmd.Flags().StringSliceVarP(&yangModuleDirs, "yang-module-dirs", "m", []string{}, "Comma separated list of dirs to be recursively searched for yang modules, or archives (.tar, .tar.gz, .tgz, .zip) as <archive>[:<glob>] where the glob selects the modules to process")
+	cmd.Flags().StringSliceVarP(&deviationModules, "deviation-modules", "", []string{}, "Comma separated list of yang deviation modules or dirs that are applied to the yang modules")
+	cmd.Flags().StringSliceVarP(&features, "features", "", []string{}, "Comma separated list of enabled features as module:feature, nodes guarded by a disabled feature are pruned. All features are enabled when not set")
+	cmd.Flags().StringVarP(&yangLibrary, "yang-library", "", "", "The RFC 8525 yang library document (json or xml) that selects the yang modules, revisions, features and deviations from the yang module and import dirs")
+	cmd.Flags().StringSliceVarP(&moduleRevisions, "module-revision", "", []string{}, "Comma separated list of pinned module revisions as module@revision, required when multiple revisions of a yang module are read")
+	cmd.Flags().BoolVarP(&strict, "strict", "", false, "Fail on the errors of processing the yang modules, otherwise the errors are reported as warnings")
+	cmd.Flags().StringVarP(&resourceMapInputFile, "resource-map-input", "r", "", "The resource map input file which resource should be generated")
+	cobra.CheckErr(cmd.MarkFlagRequired("yang-module-dirs"))
+	cobra.CheckErr(cmd.MarkFlagRequired("resource-map-input"))
+}
+
+// getYangInputOptions returns the generator options of the yang input flags, the features
+// are only restricted when the flag is set
+func getYangInputOptions(cmd *cobra.Command) []generator.Option {
+	opts := []generator.Option{
+		generator.WithYangImportDirs(yangImportDirs),
+		generator.WithYangModuleDirs(yangModuleDirs),
+		generator.WithDeviationModules(deviationModules),
+		generator.WithYangLibrary(yangLibrary),
+		generator.WithModuleRevisions(moduleRevisions),
+		generator.WithStrict(strict),
+		generator.WithResourceMapInputFile(resourceMapInputFile),
+	}
+	if cmd.Flags().Changed("features") {
+		opts = append(opts, generator.WithFeatures(features))
+	}
+	return opts
+}
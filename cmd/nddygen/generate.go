@@ -17,6 +17,8 @@ limitations under the License.
 package nddygen
 
 import (
+	"fmt"
+
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/yndd/ndd-runtime/pkg/logging"
@@ -25,17 +27,23 @@ import (
 )
 
 var (
-	yangImportDirs       []string
-	yangModuleDirs       []string
-	resourceMapInputFile string
-	healthState          bool
-	resourceMapAll       bool
-	resourceschema       bool
-	outputDir            string
-	packageName          string
-	version              string
-	prefix               string
-	apiGroup             string
+	deepCopy       bool
+	rfc7951        bool
+	controllers    bool
+	goModule       string
+	healthState    bool
+	resourceMapAll bool
+	resourceschema bool
+	outputDir      string
+	packageName    string
+	version        string
+	prefix         string
+	apiGroup       string
+	templateDir    string
+	crdOutputDir   string
+	jobs           int
+	dryRun         bool
+	showDiff       bool
 )
 
 const (
@@ -55,9 +63,6 @@ var generateCmd = &cobra.Command{
 
 		opts := []generator.Option{
 			generator.WithHealthStatus(healthState),
-			generator.WithYangImportDirs(yangImportDirs),
-			generator.WithYangModuleDirs(yangModuleDirs),
-			generator.WithResourceMapInputFile(resourceMapInputFile),
 			generator.WithResourceMapAll(resourceMapAll),
 			generator.WithPackageName(packageName),
 			generator.WithVersion(version),
@@ -67,7 +72,13 @@ var generateCmd = &cobra.Command{
 			generator.WithDebug(debug),
 			generator.WithOutputDir(outputDir),
 			generator.WithLocalRender(true),
+			generator.WithTemplateDir(templateDir),
+			generator.WithCrdOutputDir(crdOutputDir),
+			generator.WithGoModule(goModule),
+			generator.WithJobs(jobs),
+			generator.WithDryRun(dryRun || showDiff),
 		}
+		opts = append(opts, getYangInputOptions(cmd)...)
 		g, err := generator.NewGenerator(opts...)
 		if err != nil {
 			return errors.Wrap(err, errCreateGenerator)
@@ -92,19 +103,58 @@ var generateCmd = &cobra.Command{
 				log.Debug("Error", "error", err)
 				return err
 			}
+			// the api types are registered in the scheme, which requires the deepcopy
+			// functions, when the deepcopy functions are not rendered they have to be
+			// generated with controller-gen before the api package compiles
+			if deepCopy || controllers {
+				if err := g.RenderDeepCopy(); err != nil {
+					log.Debug("Error", "error", err)
+					return err
+				}
+			}
+			// the controllers convert the resources with the RFC 7951 conversion functions
+			if rfc7951 || controllers {
+				if err := g.RenderRFC7951(); err != nil {
+					log.Debug("Error", "error", err)
+					return err
+				}
+			}
+			if controllers {
+				if err := g.RenderControllers(); err != nil {
+					log.Debug("Error", "error", err)
+					return err
+				}
+			}
+		}
+
+		if crdOutputDir != "" {
+			if err := g.RenderCRDs(); err != nil {
+				log.Debug("Error", "error", err)
+				return err
+			}
 		}
 
 		//g.ShowActualPathPerResource()
 
+		if dryRun || showDiff {
+			return printFileChanges(g, showDiff)
+		}
+
+		if err := g.RemoveStaleFiles(); err != nil {
+			log.Debug("Error", "error", err)
+			return err
+		}
+		if err := g.WriteManifest(); err != nil {
+			log.Debug("Error", "error", err)
+			return err
+		}
 		return nil
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(generateCmd)
-	generateCmd.Flags().StringSliceVarP(&yangImportDirs, "yang-import-dirs", "i", []string{"/Users/henderiw/CodeProjects/go-dev/ndd-ygen/conf/yang/21_03_0/ietf/"}, "Comma separated list of dirs to be recursively searched for import modules.")
-	generateCmd.Flags().StringSliceVarP(&yangModuleDirs, "yang-module-dirs", "m", []string{"/Users/henderiw/CodeProjects/go-dev/ndd-ygen/conf/yang/21_03_0/srl/"}, "Comma separated list of dirs to be recursively searched for yang modules")
-	generateCmd.Flags().StringVarP(&resourceMapInputFile, "resource-map-input", "r", "/Users/henderiw/CodeProjects/yang/ndd/srl.yaml", "The resource map input file which resource should be generated")
+	addYangInputFlags(generateCmd)
 	generateCmd.Flags().BoolVarP(&resourceMapAll, "resource-map-full", "f", false, "generates the full resource map")
 	generateCmd.Flags().StringVarP(&outputDir, "output-dir", "o", "out/", "The directory that the Go package should be written to.")
 	generateCmd.Flags().StringVarP(&packageName, "package-name", "p", "tfsrl", "The packageName the code will generate")
@@ -113,4 +163,37 @@ func init() {
 	generateCmd.Flags().StringVarP(&prefix, "prefix", "a", "srl", "The prefix that is added to the kubernetes api resource")
 	generateCmd.Flags().BoolVarP(&resourceschema, "schema", "x", false, "The schema flag allows to generate the yang schema")
 	generateCmd.Flags().BoolVarP(&healthState, "health-state", "s", false, "The schema needs healthstate")
+	generateCmd.Flags().StringVarP(&crdOutputDir, "crd-output", "", "", "The directory the CRD manifests should be written to, no CRDs are generated when empty")
+	generateCmd.Flags().BoolVarP(&deepCopy, "deepcopy", "", true, "Generate the deepcopy functions of the api types in zz_generated.deepcopy.go, the scheme registration of the api types requires them. Set to false to generate them with controller-gen instead")
+	generateCmd.Flags().BoolVarP(&rfc7951, "rfc7951", "", false, "Generate the functions that convert the api types to the RFC 7951 JSON encoding of the resources and back")
+	generateCmd.Flags().BoolVarP(&controllers, "controllers", "", false, "Generate a reconciler package per resource in internal/controllers of the output directory, requires --go-module and implies --deepcopy and --rfc7951")
+	generateCmd.Flags().StringVarP(&goModule, "go-module", "", "", "The go module of the output directory, e.g. github.com/yndd/ndd-provider-srl")
+	generateCmd.Flags().IntVarP(&jobs, "jobs", "j", 0, "The number of files that are read and rendered concurrently, defaults to the number of CPUs")
+	generateCmd.Flags().BoolVarP(&dryRun, "dry-run", "", false, "Render the files in memory and print the files that would be created, changed or removed")
+	generateCmd.Flags().BoolVarP(&showDiff, "diff", "", false, "Render the files in memory and print the unified diff against the output directories, implies --dry-run")
+	generateCmd.Flags().StringVarP(&templateDir, "template-dir", "t", "", "The directory with templates that override the built-in templates by name")
+}
+
+// printFileChanges prints the files that are created, changed or removed by the render
+// and optionally the unified diff of the files
+func printFileChanges(g *generator.Generator, showDiff bool) error {
+	changes, err := g.GetFileChanges()
+	if err != nil {
+		return err
+	}
+	for _, fc := range changes {
+		if !showDiff {
+			fmt.Printf("%s %s\n", fc.Action, fc.Path)
+			continue
+		}
+		diff, err := fc.Diff()
+		if err != nil {
+			return err
+		}
+		fmt.Print(diff)
+	}
+	if len(changes) == 0 {
+		fmt.Println("no changes")
+	}
+	return nil
 }
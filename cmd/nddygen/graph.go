@@ -0,0 +1,87 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nddygen
+
+import (
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/yndd/ndd-runtime/pkg/logging"
+	"github.com/yndd/ndd-ygen/pkg/generator"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+var (
+	graphFormat     string
+	graphOutputFile string
+)
+
+const (
+	errWriteGraph = "cannot write graph"
+)
+
+// graphCmd represents the graph command
+var graphCmd = &cobra.Command{
+	Use:          "graph",
+	Short:        "output the resource hierarchy and leafref dependencies",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		zlog := zap.New(zap.UseDevMode(debug), zap.JSONEncoder())
+		log := logging.NewLogrLogger(zlog.WithName("nddgenyang"))
+		log.Debug("graph resources ...")
+
+		opts := []generator.Option{
+			generator.WithHealthStatus(healthState),
+			generator.WithPrefix(prefix),
+			generator.WithLogging(log),
+			generator.WithDebug(debug),
+		}
+		opts = append(opts, getYangInputOptions(cmd)...)
+		g, err := generator.NewGenerator(opts...)
+		if err != nil {
+			return errors.Wrap(err, errCreateGenerator)
+		}
+		if err := g.Run(); err != nil {
+			return err
+		}
+
+		var w io.Writer = os.Stdout
+		if graphOutputFile != "" {
+			f, err := os.Create(graphOutputFile)
+			if err != nil {
+				return errors.Wrap(err, errWriteGraph)
+			}
+			defer f.Close()
+			w = f
+		}
+		if err := g.GetGraph().Write(w, graphFormat); err != nil {
+			return errors.Wrap(err, errWriteGraph)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(graphCmd)
+	addYangInputFlags(graphCmd)
+	graphCmd.Flags().StringVarP(&prefix, "prefix", "a", "srl", "The prefix that is added to the kubernetes api resource")
+	graphCmd.Flags().BoolVarP(&healthState, "health-state", "s", false, "The schema needs healthstate")
+	graphCmd.Flags().StringVarP(&graphFormat, "format", "", generator.GraphFormatDOT, "The output format of the graph: dot, mermaid or json")
+	graphCmd.Flags().StringVarP(&graphOutputFile, "output", "o", "", "The file the graph is written to, defaults to stdout")
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nddygen
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/yndd/ndd-ygen/pkg/generator"
+)
+
+const (
+	errResourceMapSchema = "cannot generate the resource map schema"
+)
+
+// schemaCmd represents the schema command
+var schemaCmd = &cobra.Command{
+	Use:          "schema",
+	Short:        "print the JSON schema of the input formats",
+	SilenceUsage: true,
+}
+
+// schemaResourceMapCmd represents the schema resource-map command
+var schemaResourceMapCmd = &cobra.Command{
+	Use:          "resource-map",
+	Short:        "print the JSON schema of the resource map",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		b, err := generator.ResourceMapJSONSchema()
+		if err != nil {
+			return errors.Wrap(err, errResourceMapSchema)
+		}
+		fmt.Println(string(b))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+	schemaCmd.AddCommand(schemaResourceMapCmd)
+}
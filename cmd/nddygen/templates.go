@@ -0,0 +1,57 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nddygen
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/yndd/ndd-ygen/pkg/templ"
+)
+
+var (
+	templateExportDir string
+)
+
+const (
+	errExportTemplates = "cannot export templates"
+)
+
+// templatesCmd represents the templates command
+var templatesCmd = &cobra.Command{
+	Use:          "templates",
+	Short:        "manage the built-in templates",
+	SilenceUsage: true,
+}
+
+// templatesExportCmd represents the templates export command
+var templatesExportCmd = &cobra.Command{
+	Use:          "export",
+	Short:        "export the built-in templates for customization",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := templ.ExportTemplates(templateExportDir); err != nil {
+			return errors.Wrap(err, errExportTemplates)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(templatesCmd)
+	templatesCmd.AddCommand(templatesExportCmd)
+	templatesExportCmd.Flags().StringVarP(&templateExportDir, "output-dir", "o", "templates/", "The directory the built-in templates are written to.")
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nddygen
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/yndd/ndd-runtime/pkg/logging"
+	"github.com/yndd/ndd-ygen/pkg/generator"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+const (
+	errValidateResourceMap = "resource map validation failed"
+)
+
+// validateCmd represents the validate command
+var validateCmd = &cobra.Command{
+	Use:          "validate",
+	Short:        "validate the resource map against the yang modules",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		zlog := zap.New(zap.UseDevMode(debug), zap.JSONEncoder())
+		log := logging.NewLogrLogger(zlog.WithName("nddgenyang"))
+		log.Debug("validate resource map ...")
+
+		opts := []generator.Option{
+			generator.WithLogging(log),
+			generator.WithDebug(debug),
+		}
+		opts = append(opts, getYangInputOptions(cmd)...)
+		g, err := generator.NewGenerator(opts...)
+		if err != nil {
+			return errors.Wrap(err, errCreateGenerator)
+		}
+
+		if err := g.Validate(); err != nil {
+			return errors.Wrap(err, errValidateResourceMap)
+		}
+		fmt.Printf("resource map %s is valid\n", resourceMapInputFile)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+	addYangInputFlags(validateCmd)
+}
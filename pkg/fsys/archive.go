@@ -0,0 +1,185 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fsys
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	errUnknownArchive = "unknown archive format, expected .tar, .tar.gz, .tgz or .zip"
+	errReadArchive    = "cannot read archive"
+)
+
+var archiveExtensions = []string{".tar.gz", ".tgz", ".tar", ".zip"}
+
+// IsArchive returns true if the file name has the extension of a supported archive
+func IsArchive(name string) bool {
+	for _, ext := range archiveExtensions {
+		if strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// SplitArchivePath splits a path of the form <archive>[:<glob>] in the archive and the
+// glob pattern of the files in the archive. It returns false if the path is not an archive.
+func SplitArchivePath(p string) (string, string, bool) {
+	if IsArchive(p) {
+		return p, "", true
+	}
+	if i := strings.LastIndex(p, ":"); i > 0 && IsArchive(p[:i]) {
+		return p[:i], p[i+1:], true
+	}
+	return "", "", false
+}
+
+// NewArchiveFS returns a filesystem in memory with the regular files of the archive,
+// the format of the archive is derived from the extension of the name
+func NewArchiveFS(name string, data []byte) (*MemFS, error) {
+	f := NewMemFS()
+	var err error
+	switch {
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		var zr *gzip.Reader
+		zr, err = gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s %s", errReadArchive, name)
+		}
+		err = readTar(f, zr)
+	case strings.HasSuffix(name, ".tar"):
+		err = readTar(f, bytes.NewReader(data))
+	case strings.HasSuffix(name, ".zip"):
+		err = readZip(f, data)
+	default:
+		return nil, errors.Wrap(errors.New(errUnknownArchive), name)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s %s", errReadArchive, name)
+	}
+	return f, nil
+}
+
+func readTar(f *MemFS, r io.Reader) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		b, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return err
+		}
+		if err := f.WriteFile(cleanArchivePath(hdr.Name), b, hdr.FileInfo().Mode()); err != nil {
+			return err
+		}
+	}
+}
+
+func readZip(f *MemFS, data []byte) error {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return err
+	}
+	for _, zf := range zr.File {
+		if !zf.Mode().IsRegular() {
+			continue
+		}
+		rc, err := zf.Open()
+		if err != nil {
+			return err
+		}
+		b, err := ioutil.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+		if err := f.WriteFile(cleanArchivePath(zf.Name), b, zf.Mode()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cleanArchivePath returns the path of a file in the archive relative to the root of the archive
+func cleanArchivePath(p string) string {
+	return filepath.FromSlash(strings.TrimPrefix(path.Clean("/"+p), "/"))
+}
+
+// Glob returns the names of the files that match the pattern sorted by name,
+// see MatchGlob for the syntax of the pattern
+func (f *MemFS) Glob(pattern string) ([]string, error) {
+	names := make([]string, 0)
+	for _, name := range f.GetFileNames() {
+		ok, err := MatchGlob(pattern, filepath.ToSlash(name))
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// MatchGlob reports whether the slash separated name matches the pattern. The pattern
+// has the syntax of path.Match per path element, in addition the element ** matches
+// zero or more path elements.
+func MatchGlob(pattern, name string) (bool, error) {
+	return matchElems(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchElems(pattern, elems []string) (bool, error) {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(elems); i++ {
+				ok, err := matchElems(pattern[1:], elems[i:])
+				if ok || err != nil {
+					return ok, err
+				}
+			}
+			return false, nil
+		}
+		if len(elems) == 0 {
+			return false, nil
+		}
+		ok, err := path.Match(pattern[0], elems[0])
+		if !ok || err != nil {
+			return false, err
+		}
+		pattern, elems = pattern[1:], elems[1:]
+	}
+	return len(elems) == 0, nil
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fsys
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// archiveEntry is a file in a test archive, the name is used as is such that
+// entries that escape the root of the archive can be written
+type archiveEntry struct {
+	name string
+	data string
+	dir  bool
+}
+
+var testArchiveEntries = []archiveEntry{
+	{name: "models/", dir: true},
+	{name: "models/a.yang", data: "module a {}"},
+	{name: "models/common/b.yang", data: "module b {}"},
+	{name: "README.md", data: "readme"},
+	{name: "../../escape.yang", data: "module escape {}"},
+	{name: "/abs/c.yang", data: "module c {}"},
+	{name: "models/../d.yang", data: "module d {}"},
+}
+
+// the files of testArchiveEntries in the archive filesystem
+var testArchiveFiles = map[string]string{
+	"README.md":                       "readme",
+	filepath.Join("abs", "c.yang"):    "module c {}",
+	"d.yang":                          "module d {}",
+	"escape.yang":                     "module escape {}",
+	filepath.Join("models", "a.yang"): "module a {}",
+	filepath.Join("models", "common", "b.yang"): "module b {}",
+}
+
+func writeTestTar(t *testing.T, entries []archiveEntry) []byte {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, e := range entries {
+		hdr := &tar.Header{Typeflag: tar.TypeReg, Name: e.name, Size: int64(len(e.data)), Mode: 0644}
+		if e.dir {
+			hdr = &tar.Header{Typeflag: tar.TypeDir, Name: e.name, Mode: 0755}
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(e.data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// a symbolic link is not a regular file and is skipped
+	if err := tw.WriteHeader(&tar.Header{Typeflag: tar.TypeSymlink, Name: "link.yang", Linkname: "/etc/passwd"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func writeTestTarGz(t *testing.T, entries []archiveEntry) []byte {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(writeTestTar(t, entries)); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func writeTestZip(t *testing.T, entries []archiveEntry) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, e := range entries {
+		fh := &zip.FileHeader{Name: e.name, Method: zip.Deflate}
+		fh.SetMode(0644)
+		if e.dir {
+			fh.SetMode(os.ModeDir | 0755)
+		}
+		w, err := zw.CreateHeader(fh)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(e.data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestNewArchiveFS(t *testing.T) {
+	cases := map[string]struct {
+		name  string
+		write func(t *testing.T, entries []archiveEntry) []byte
+	}{
+		"Tar":   {name: "yang.tar", write: writeTestTar},
+		"TarGz": {name: "yang.tar.gz", write: writeTestTarGz},
+		"Tgz":   {name: "yang.tgz", write: writeTestTarGz},
+		"Zip":   {name: "yang.zip", write: writeTestZip},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			f, err := NewArchiveFS(tc.name, tc.write(t, testArchiveEntries))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := map[string]string{}
+			for _, name := range f.GetFileNames() {
+				b, err := f.ReadFile(name)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got[name] = string(b)
+			}
+			// the entries that escape the root of the archive are kept within the root
+			if !reflect.DeepEqual(got, testArchiveFiles) {
+				t.Errorf("got files %v, want %v", got, testArchiveFiles)
+			}
+			if fi, err := f.Stat("models"); err != nil || !fi.IsDir() {
+				t.Errorf("expected the directory models, got %v", err)
+			}
+		})
+	}
+}
+
+func TestNewArchiveFSErrors(t *testing.T) {
+	cases := map[string]struct {
+		name string
+		data []byte
+	}{
+		"UnknownFormat": {name: "yang.rar", data: []byte("rar")},
+		"InvalidGzip":   {name: "yang.tgz", data: []byte("not gzip")},
+		"InvalidTarGz":  {name: "yang.tar.gz", data: writeTestGzip(t, []byte("not a tar archive, but long enough to be read as a header"))},
+		"InvalidZip":    {name: "yang.zip", data: []byte("not zip")},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewArchiveFS(tc.name, tc.data); err == nil {
+				t.Errorf("expected an error for %s", tc.name)
+			}
+		})
+	}
+}
+
+func writeTestGzip(t *testing.T, data []byte) []byte {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(bytes.Repeat(data, 20)); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestMemFSGlob(t *testing.T) {
+	f, err := NewArchiveFS("yang.zip", writeTestZip(t, testArchiveEntries))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := map[string]struct {
+		pattern string
+		want    []string
+		wantErr bool
+	}{
+		"AllYang": {
+			pattern: "**/*.yang",
+			want: []string{
+				filepath.Join("abs", "c.yang"),
+				"d.yang",
+				"escape.yang",
+				filepath.Join("models", "a.yang"),
+				filepath.Join("models", "common", "b.yang"),
+			},
+		},
+		"Directory": {
+			pattern: "models/*.yang",
+			want:    []string{filepath.Join("models", "a.yang")},
+		},
+		"NoMatch": {
+			pattern: "other/**/*.yang",
+			want:    []string{},
+		},
+		"BadPattern": {
+			pattern: "models/[.yang",
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := f.Glob(tc.pattern)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Glob(%q): got error %v, want error %t", tc.pattern, err, tc.wantErr)
+			}
+			if !tc.wantErr && !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Glob(%q): got %v, want %v", tc.pattern, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,264 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fsys
+
+import (
+	"archive/tar"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FS is the filesystem the generator reads its input from
+type FS interface {
+	Stat(name string) (os.FileInfo, error)
+	ReadFile(name string) ([]byte, error)
+	// ReadDir returns the entries of the directory sorted by name
+	ReadDir(name string) ([]os.FileInfo, error)
+}
+
+// WriteFS is the filesystem the generator writes its output to
+type WriteFS interface {
+	FS
+	MkdirAll(name string, perm os.FileMode) error
+	WriteFile(name string, data []byte, perm os.FileMode) error
+	Remove(name string) error
+}
+
+// OSFS is the filesystem of the operating system
+type OSFS struct{}
+
+// NewOSFS returns the filesystem of the operating system
+func NewOSFS() *OSFS {
+	return &OSFS{}
+}
+
+func (f *OSFS) Stat(name string) (os.FileInfo, error) {
+	return os.Stat(name)
+}
+
+func (f *OSFS) ReadFile(name string) ([]byte, error) {
+	return ioutil.ReadFile(name)
+}
+
+func (f *OSFS) ReadDir(name string) ([]os.FileInfo, error) {
+	return ioutil.ReadDir(name)
+}
+
+func (f *OSFS) MkdirAll(name string, perm os.FileMode) error {
+	return os.MkdirAll(name, perm)
+}
+
+func (f *OSFS) WriteFile(name string, data []byte, perm os.FileMode) error {
+	return ioutil.WriteFile(name, data, perm)
+}
+
+func (f *OSFS) Remove(name string) error {
+	return os.Remove(name)
+}
+
+// MemFS is a filesystem in memory, the directories of the files are implicit.
+// It is safe for concurrent use.
+type MemFS struct {
+	m     sync.RWMutex
+	files map[string]*memFile
+	dirs  map[string]bool
+}
+
+type memFile struct {
+	data []byte
+	perm os.FileMode
+}
+
+// NewMemFS returns an empty filesystem in memory
+func NewMemFS() *MemFS {
+	return &MemFS{
+		files: make(map[string]*memFile),
+		dirs:  make(map[string]bool),
+	}
+}
+
+func (f *MemFS) Stat(name string) (os.FileInfo, error) {
+	f.m.RLock()
+	defer f.m.RUnlock()
+	name = filepath.Clean(name)
+	if mf, ok := f.files[name]; ok {
+		return &memFileInfo{name: filepath.Base(name), size: int64(len(mf.data)), mode: mf.perm}, nil
+	}
+	if f.isDir(name) {
+		return &memFileInfo{name: filepath.Base(name), mode: os.ModeDir | 0755}, nil
+	}
+	return nil, &os.PathError{Op: "stat", Path: name, Err: os.ErrNotExist}
+}
+
+func (f *MemFS) ReadFile(name string) ([]byte, error) {
+	f.m.RLock()
+	defer f.m.RUnlock()
+	mf, ok := f.files[filepath.Clean(name)]
+	if !ok {
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	return append([]byte{}, mf.data...), nil
+}
+
+func (f *MemFS) ReadDir(name string) ([]os.FileInfo, error) {
+	f.m.RLock()
+	defer f.m.RUnlock()
+	name = filepath.Clean(name)
+	if !f.isDir(name) {
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	entries := map[string]os.FileInfo{}
+	for p, mf := range f.files {
+		if rel, ok := relChild(name, p); ok {
+			if i := strings.Index(rel, string(filepath.Separator)); i >= 0 {
+				entries[rel[:i]] = &memFileInfo{name: rel[:i], mode: os.ModeDir | 0755}
+			} else {
+				entries[rel] = &memFileInfo{name: rel, size: int64(len(mf.data)), mode: mf.perm}
+			}
+		}
+	}
+	for p := range f.dirs {
+		if rel, ok := relChild(name, p); ok {
+			if i := strings.Index(rel, string(filepath.Separator)); i >= 0 {
+				rel = rel[:i]
+			}
+			entries[rel] = &memFileInfo{name: rel, mode: os.ModeDir | 0755}
+		}
+	}
+	fis := make([]os.FileInfo, 0, len(entries))
+	for _, fi := range entries {
+		fis = append(fis, fi)
+	}
+	sort.Slice(fis, func(i, j int) bool {
+		return fis[i].Name() < fis[j].Name()
+	})
+	return fis, nil
+}
+
+func (f *MemFS) MkdirAll(name string, perm os.FileMode) error {
+	f.m.Lock()
+	defer f.m.Unlock()
+	f.dirs[filepath.Clean(name)] = true
+	return nil
+}
+
+func (f *MemFS) WriteFile(name string, data []byte, perm os.FileMode) error {
+	f.m.Lock()
+	defer f.m.Unlock()
+	f.files[filepath.Clean(name)] = &memFile{data: append([]byte{}, data...), perm: perm}
+	return nil
+}
+
+func (f *MemFS) Remove(name string) error {
+	f.m.Lock()
+	defer f.m.Unlock()
+	name = filepath.Clean(name)
+	if _, ok := f.files[name]; !ok {
+		return &os.PathError{Op: "remove", Path: name, Err: os.ErrNotExist}
+	}
+	delete(f.files, name)
+	return nil
+}
+
+// GetFileNames returns the names of the files sorted by name
+func (f *MemFS) GetFileNames() []string {
+	f.m.RLock()
+	defer f.m.RUnlock()
+	names := make([]string, 0, len(f.files))
+	for name := range f.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// WriteTar writes the files as a tar archive, the archive does not depend on the
+// time the files are written such that the same files result in the same archive
+func (f *MemFS) WriteTar(w io.Writer) error {
+	tw := tar.NewWriter(w)
+	for _, name := range f.GetFileNames() {
+		f.m.RLock()
+		mf := f.files[name]
+		f.m.RUnlock()
+		if err := tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     filepath.ToSlash(name),
+			Size:     int64(len(mf.data)),
+			Mode:     int64(mf.perm.Perm()),
+			ModTime:  time.Unix(0, 0),
+		}); err != nil {
+			return err
+		}
+		if _, err := tw.Write(mf.data); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+// isDir returns true if the directory was created or holds files
+func (f *MemFS) isDir(name string) bool {
+	if name == "." || name == string(filepath.Separator) || f.dirs[name] {
+		return true
+	}
+	for p := range f.files {
+		if _, ok := relChild(name, p); ok {
+			return true
+		}
+	}
+	for p := range f.dirs {
+		if _, ok := relChild(name, p); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// relChild returns the path of p relative to the directory dir, if p is in dir
+func relChild(dir, p string) (string, bool) {
+	switch {
+	case dir == ".":
+		if filepath.IsAbs(p) {
+			return "", false
+		}
+		return p, true
+	case dir == string(filepath.Separator):
+		return strings.TrimPrefix(p, dir), filepath.IsAbs(p) && p != dir
+	default:
+		prefix := dir + string(filepath.Separator)
+		return strings.TrimPrefix(p, prefix), strings.HasPrefix(p, prefix)
+	}
+}
+
+type memFileInfo struct {
+	name string
+	size int64
+	mode os.FileMode
+}
+
+func (fi *memFileInfo) Name() string       { return fi.name }
+func (fi *memFileInfo) Size() int64        { return fi.size }
+func (fi *memFileInfo) Mode() os.FileMode  { return fi.mode }
+func (fi *memFileInfo) ModTime() time.Time { return time.Time{} }
+func (fi *memFileInfo) IsDir() bool        { return fi.mode.IsDir() }
+func (fi *memFileInfo) Sys() interface{}   { return nil }
@@ -0,0 +1,232 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fsys
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMemFS(t *testing.T) {
+	f := NewMemFS()
+	if err := f.WriteFile(filepath.Join("a", "b", "c.yang"), []byte("c"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.WriteFile(filepath.Join("a", "d.yang"), []byte("dd"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.MkdirAll(filepath.Join("a", "e", "f"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := f.ReadFile(filepath.Join("a", ".", "d.yang"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "dd" {
+		t.Errorf("ReadFile: got %q, want %q", b, "dd")
+	}
+	// the returned data is a copy
+	b[0] = 'x'
+	if b, _ := f.ReadFile(filepath.Join("a", "d.yang")); string(b) != "dd" {
+		t.Errorf("ReadFile: the file was modified through the returned data: %q", b)
+	}
+
+	fi, err := f.Stat(filepath.Join("a", "d.yang"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Name() != "d.yang" || fi.Size() != 2 || fi.Mode() != 0600 || fi.IsDir() {
+		t.Errorf("Stat: got %s %d %s, want d.yang 2 -rw-------", fi.Name(), fi.Size(), fi.Mode())
+	}
+	for _, dir := range []string{".", "a", filepath.Join("a", "b"), filepath.Join("a", "e")} {
+		fi, err := f.Stat(dir)
+		if err != nil {
+			t.Errorf("Stat(%s): %s", dir, err)
+			continue
+		}
+		if !fi.IsDir() {
+			t.Errorf("Stat(%s): expected a directory", dir)
+		}
+	}
+	if _, err := f.Stat(filepath.Join("a", "x")); !os.IsNotExist(err) {
+		t.Errorf("Stat: got %v, want a not exist error", err)
+	}
+	if _, err := f.ReadFile(filepath.Join("a", "x")); !os.IsNotExist(err) {
+		t.Errorf("ReadFile: got %v, want a not exist error", err)
+	}
+
+	fis, err := f.ReadDir("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]bool{}
+	names := make([]string, 0, len(fis))
+	for _, fi := range fis {
+		names = append(names, fi.Name())
+		got[fi.Name()] = fi.IsDir()
+	}
+	if want := []string{"b", "d.yang", "e"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("ReadDir: got %v, want %v", names, want)
+	}
+	if !got["b"] || got["d.yang"] || !got["e"] {
+		t.Errorf("ReadDir: got directories %v, want b and e", got)
+	}
+	if _, err := f.ReadDir("x"); !os.IsNotExist(err) {
+		t.Errorf("ReadDir: got %v, want a not exist error", err)
+	}
+
+	if want := []string{filepath.Join("a", "b", "c.yang"), filepath.Join("a", "d.yang")}; !reflect.DeepEqual(f.GetFileNames(), want) {
+		t.Errorf("GetFileNames: got %v, want %v", f.GetFileNames(), want)
+	}
+
+	if err := f.Remove(filepath.Join("a", "b", "c.yang")); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Remove(filepath.Join("a", "b", "c.yang")); !os.IsNotExist(err) {
+		t.Errorf("Remove: got %v, want a not exist error", err)
+	}
+	if _, err := f.Stat(filepath.Join("a", "b")); !os.IsNotExist(err) {
+		t.Errorf("Stat: got %v, want the implicit directory to be removed with its files", err)
+	}
+}
+
+func TestMemFSWriteTar(t *testing.T) {
+	f := NewMemFS()
+	files := map[string]string{
+		filepath.Join("out", "apis", "types.go"): "package apis",
+		filepath.Join("out", "main.go"):          "package main",
+		"README.md":                              "",
+	}
+	for name, data := range files {
+		if err := f.WriteFile(name, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := f.WriteTar(&buf); err != nil {
+		t.Fatal(err)
+	}
+	names := make([]string, 0)
+	tr := tar.NewReader(bytes.NewReader(buf.Bytes()))
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := ioutil.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := files[filepath.FromSlash(hdr.Name)]; string(b) != want {
+			t.Errorf("%s: got %q, want %q", hdr.Name, b, want)
+		}
+		if hdr.Mode != 0644 || !hdr.ModTime.Equal(time.Unix(0, 0)) {
+			t.Errorf("%s: got mode %o and time %s, want 644 and the epoch", hdr.Name, hdr.Mode, hdr.ModTime)
+		}
+		names = append(names, hdr.Name)
+	}
+	if want := []string{"README.md", "out/apis/types.go", "out/main.go"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("got %v, want %v", names, want)
+	}
+
+	// the same files result in the same archive
+	var again bytes.Buffer
+	if err := f.WriteTar(&again); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), again.Bytes()) {
+		t.Errorf("the archive of the same files differs")
+	}
+}
+
+func TestMatchGlob(t *testing.T) {
+	cases := map[string]struct {
+		pattern string
+		name    string
+		want    bool
+		wantErr bool
+	}{
+		"Exact":               {pattern: "a/b.yang", name: "a/b.yang", want: true},
+		"Star":                {pattern: "a/*.yang", name: "a/b.yang", want: true},
+		"StarNoSeparator":     {pattern: "*.yang", name: "a/b.yang", want: false},
+		"StarOneElement":      {pattern: "a/*/c.yang", name: "a/b/c.yang", want: true},
+		"StarTooDeep":         {pattern: "a/*/c.yang", name: "a/b/x/c.yang", want: false},
+		"DoubleStarZero":      {pattern: "a/**/c.yang", name: "a/c.yang", want: true},
+		"DoubleStarOne":       {pattern: "a/**/c.yang", name: "a/b/c.yang", want: true},
+		"DoubleStarMany":      {pattern: "a/**/c.yang", name: "a/b/x/y/c.yang", want: true},
+		"DoubleStarLeading":   {pattern: "**/*.yang", name: "a/b/c.yang", want: true},
+		"DoubleStarTrailing":  {pattern: "a/**", name: "a/b/c.yang", want: true},
+		"DoubleStarTwice":     {pattern: "**/b/**/*.yang", name: "x/b/y/z/c.yang", want: true},
+		"DoubleStarNoMatch":   {pattern: "a/**/c.yang", name: "b/x/c.yang", want: false},
+		"DoubleStarWrongBase": {pattern: "**/*.yang", name: "a/b/c.txt", want: false},
+		"PatternTooLong":      {pattern: "a/b/c.yang", name: "a/b", want: false},
+		"CharacterClass":      {pattern: "a/[bc].yang", name: "a/c.yang", want: true},
+		"BadPattern":          {pattern: "a/[.yang", name: "a/b.yang", wantErr: true},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := MatchGlob(tc.pattern, tc.name)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("MatchGlob(%q, %q): got error %v, want error %t", tc.pattern, tc.name, err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("MatchGlob(%q, %q): got %t, want %t", tc.pattern, tc.name, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSplitArchivePath(t *testing.T) {
+	cases := map[string]struct {
+		path        string
+		wantArchive string
+		wantGlob    string
+		wantOk      bool
+	}{
+		"Tar":            {path: "yang.tar", wantArchive: "yang.tar", wantOk: true},
+		"TarGz":          {path: "dir/yang.tar.gz", wantArchive: "dir/yang.tar.gz", wantOk: true},
+		"Tgz":            {path: "yang.tgz", wantArchive: "yang.tgz", wantOk: true},
+		"Zip":            {path: "yang.zip", wantArchive: "yang.zip", wantOk: true},
+		"Glob":           {path: "yang.zip:models/**/*.yang", wantArchive: "yang.zip", wantGlob: "models/**/*.yang", wantOk: true},
+		"EmptyGlob":      {path: "yang.tgz:", wantArchive: "yang.tgz", wantOk: true},
+		"ColonInArchive": {path: "c:/yang.tar:*.yang", wantArchive: "c:/yang.tar", wantGlob: "*.yang", wantOk: true},
+		"Directory":      {path: "yang/models", wantOk: false},
+		"DirectoryColon": {path: "yang:models", wantOk: false},
+		"UnknownFormat":  {path: "yang.rar", wantOk: false},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			archive, glob, ok := SplitArchivePath(tc.path)
+			if archive != tc.wantArchive || glob != tc.wantGlob || ok != tc.wantOk {
+				t.Errorf("SplitArchivePath(%q): got %q %q %t, want %q %q %t",
+					tc.path, archive, glob, ok, tc.wantArchive, tc.wantGlob, tc.wantOk)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"sort"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/stoewer/go-strcase"
+	"github.com/yndd/ndd-yang/pkg/container"
+)
+
+// ChoiceCase records the yang choice and case a container entry belongs to
+type ChoiceCase struct {
+	Choice    string
+	Case      string
+	Mandatory bool // the choice is mandatory
+}
+
+// Choice holds the cases of a yang choice within a container
+type Choice struct {
+	Name      string
+	Mandatory bool
+	Cases     []*Case
+}
+
+// Case holds the names of the container entries that belong to a case
+type Case struct {
+	Name    string
+	Entries []string
+}
+
+// setEntryChoiceCases records the choices and cases of the yang entry on the container entry,
+// since choice and case nodes are flattened in the container tree
+func (g *Generator) setEntryChoiceCases(ce *container.Entry, e *yang.Entry) {
+	if cc := getChoiceCases(e); len(cc) > 0 {
+		g.entryChoiceCases[ce] = cc
+	}
+}
+
+// GetEntryChoiceCases returns the choices and cases the container entry belongs to,
+// ordered from the outermost to the innermost choice
+func (g *Generator) GetEntryChoiceCases(ce *container.Entry) []*ChoiceCase {
+	return g.entryChoiceCases[ce]
+}
+
+// getChoiceCases walks the choice and case parents of the yang entry
+func getChoiceCases(e *yang.Entry) []*ChoiceCase {
+	cc := make([]*ChoiceCase, 0)
+	for p := e.Parent; p != nil && (p.IsCase() || p.IsChoice()); p = p.Parent {
+		if p.IsCase() && p.Parent != nil {
+			cc = append([]*ChoiceCase{{
+				Choice:    p.Parent.Name,
+				Case:      p.Name,
+				Mandatory: p.Parent.Mandatory == yang.TSTrue,
+			}}, cc...)
+		}
+	}
+	return cc
+}
+
+// GetContainerChoices returns the choices within the container, sorted by name
+func (g *Generator) GetContainerChoices(c *container.Container) []*Choice {
+	choices := map[string]*Choice{}
+	cases := map[string]map[string]*Case{}
+	for _, e := range c.GetEntries() {
+		for _, cc := range g.GetEntryChoiceCases(e) {
+			if _, ok := choices[cc.Choice]; !ok {
+				choices[cc.Choice] = &Choice{Name: cc.Choice, Mandatory: cc.Mandatory}
+				cases[cc.Choice] = map[string]*Case{}
+			}
+			if _, ok := cases[cc.Choice][cc.Case]; !ok {
+				cases[cc.Choice][cc.Case] = &Case{Name: cc.Case}
+				choices[cc.Choice].Cases = append(choices[cc.Choice].Cases, cases[cc.Choice][cc.Case])
+			}
+			cases[cc.Choice][cc.Case].Entries = append(cases[cc.Choice][cc.Case].Entries, e.GetName())
+		}
+	}
+	result := make([]*Choice, 0, len(choices))
+	for _, ch := range choices {
+		sort.Slice(ch.Cases, func(i, j int) bool { return ch.Cases[i].Name < ch.Cases[j].Name })
+		result = append(result, ch)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// getChoiceEntries returns the names of the entries of the container that belong to a case
+func (g *Generator) getChoiceEntries(c *container.Container) map[string]bool {
+	entries := map[string]bool{}
+	for _, e := range c.GetEntries() {
+		if len(g.GetEntryChoiceCases(e)) > 0 {
+			entries[e.GetName()] = true
+		}
+	}
+	return entries
+}
+
+// getContainerDefaults returns the defaults of the entries of the container, the defaults of
+// the entries that belong to a case are omitted since a default would select the case
+func (g *Generator) getContainerDefaults(c *container.Container) map[string]string {
+	choiceEntries := g.getChoiceEntries(c)
+	defaults := map[string]string{}
+	for name, d := range c.GetDefaults() {
+		if !choiceEntries[name] {
+			defaults[name] = d
+		}
+	}
+	return defaults
+}
+
+// getChoiceSchema returns the mutual exclusion of the cases of a choice as a oneOf constraint,
+// a case is set when any of its entries is set. When the choice is not mandatory the
+// constraint also matches when no case is set.
+func getChoiceSchema(ch *Choice) *JSONSchemaProps {
+	s := &JSONSchemaProps{}
+	anyCase := &JSONSchemaProps{}
+	for _, cs := range ch.Cases {
+		caseSet := &JSONSchemaProps{}
+		for _, name := range cs.Entries {
+			caseSet.AnyOf = append(caseSet.AnyOf, &JSONSchemaProps{Required: []string{strcase.KebabCase(name)}})
+		}
+		s.OneOf = append(s.OneOf, caseSet)
+		anyCase.AnyOf = append(anyCase.AnyOf, caseSet.AnyOf...)
+	}
+	if !ch.Mandatory {
+		s.OneOf = append(s.OneOf, &JSONSchemaProps{Not: anyCase})
+	}
+	return s
+}
@@ -16,24 +16,34 @@ limitations under the License.
 
 package generator
 
+import (
+	"runtime"
+)
+
 // ResourceYamlInput struct
 type ResourceYamlInput struct {
-	Schema        string                 `yaml:"schema"`
-	Path          map[string]PathDetails `yaml:"path"`
-	StaticLeafref map[string]string      `yaml:"static-leafref"`
+	Schema        string                 `yaml:"schema" description:"the path prefix of the yang schema that is generated with the full resource map"`
+	Path          map[string]PathDetails `yaml:"path" description:"the resources to generate, keyed by the absolute path of the resource"`
+	StaticLeafref map[string]string      `yaml:"static-leafref" description:"leafrefs that are not expressed in yang, keyed by the local path with the remote path as value"`
 }
 
 // PathDetails struct
 type PathDetails struct {
 	//SubResources []string               `yaml:"sub-resources"`
-	Excludes  []string               `yaml:"excludes"`
-	Hierarchy map[string]PathDetails `yaml:"hierarchy"`
+	Excludes  []string               `yaml:"excludes" description:"paths relative to the resource that are excluded from the resource"`
+	Hierarchy map[string]PathDetails `yaml:"hierarchy" description:"the child resources, keyed by the path relative to the resource"`
 }
 
 type Config struct {
 	yangImportDirs []string // the YANG files we need to import to prcess the YANG resource files
 	yangModuleDirs []string // the YANG resource files
 
+	deviationModules []string // the YANG deviation modules that are applied to the YANG resource files
+	features         []string // the enabled features as module:feature, nil when all features are enabled
+	yangLibrary      string   // the RFC 8525 yang library document that selects the YANG modules
+	moduleRevisions  []string // the pinned revisions of the YANG modules as module@revision
+	strict           bool     // the errors of goyang when processing the YANG modules are fatal
+
 	resourceMapInputFile string // the resource input file
 	resourceMapAll       bool   // resource map all
 	outputDir            string // the directory where the resource should be written to
@@ -41,6 +51,11 @@ type Config struct {
 	version              string // the version of the api we generate for k8s
 	apiGroup             string // the apigroup we generate for k8s
 	prefix               string // the prefix that is addded to the k8s resource api
+	templateDir          string // the directory with templates that override the built-in templates
+	crdOutputDir         string // the directory where the crd manifests should be written to
+	exampleOutputDir     string // the directory where the example custom resources should be written to
+	goModule             string // the go module of the output directory, used in the imports of the controllers
+	jobs                 int    // the number of files that are read and rendered concurrently
 }
 
 func (c *Config) GetYangImportDirs() []string {
@@ -51,6 +66,26 @@ func (c *Config) GetYangModuleDirs() []string {
 	return c.yangModuleDirs
 }
 
+func (c *Config) GetDeviationModules() []string {
+	return c.deviationModules
+}
+
+func (c *Config) GetFeatures() []string {
+	return c.features
+}
+
+func (c *Config) GetYangLibrary() string {
+	return c.yangLibrary
+}
+
+func (c *Config) GetModuleRevisions() []string {
+	return c.moduleRevisions
+}
+
+func (c *Config) GetStrict() bool {
+	return c.strict
+}
+
 func (c *Config) GetResourceMapInputFile() string {
 	return c.resourceMapInputFile
 }
@@ -78,3 +113,26 @@ func (c *Config) GetApiGroup() string {
 func (c *Config) GetPrefix() string {
 	return c.prefix
 }
+
+func (c *Config) GetTemplateDir() string {
+	return c.templateDir
+}
+
+func (c *Config) GetCrdOutputDir() string {
+	return c.crdOutputDir
+}
+
+func (c *Config) GetExampleOutputDir() string {
+	return c.exampleOutputDir
+}
+
+func (c *Config) GetGoModule() string {
+	return c.goModule
+}
+
+func (c *Config) GetJobs() int {
+	if c.jobs < 1 {
+		return runtime.NumCPU()
+	}
+	return c.jobs
+}
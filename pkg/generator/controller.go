@@ -0,0 +1,209 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"bytes"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/pkg/errors"
+	"github.com/stoewer/go-strcase"
+	"github.com/yndd/ndd-yang/pkg/resource"
+	"github.com/yndd/ndd-yang/pkg/yparser"
+)
+
+const (
+	errNoGoModule        = "the go module of the output directory is required to render the controllers"
+	errControllerPathKey = "the key of the resource path is not part of the custom resource, map the list as a parent resource"
+
+	// controllersDir is the directory of the controllers relative to the output directory
+	controllersDir = "internal/controllers"
+)
+
+// Controller is the reconciler package rendered for a resource
+type Controller struct {
+	Package                string
+	ImportPath             string
+	APIPackage             string
+	Version                string
+	ResourceName           string
+	ResourceNameWithPrefix string
+	Path                   []*ControllerPathElem
+}
+
+// ControllerPathElem is an element of the gnmi path of a resource
+type ControllerPathElem struct {
+	Name string
+	Keys []*ControllerPathKey
+}
+
+// ControllerPathKey is a key of a gnmi path element with the go expression that returns
+// the value of the key from the parameters of the custom resource
+type ControllerPathKey struct {
+	Name  string
+	Value string
+}
+
+// RenderControllers writes a reconciler package per resource to
+// <outputDir>/internal/controllers/<resource>/<resource>.go and the setup of all
+// controllers to <outputDir>/internal/controllers/controllers.go
+func (g *Generator) RenderControllers() error {
+	if g.GetConfig().GetGoModule() == "" {
+		return errors.New(errNoGoModule)
+	}
+	g.startRender(renderKindControllers)
+	controllers := make([]*Controller, 0)
+	for _, r := range g.getRenderResources() {
+		if r.RootContainer == nil {
+			return errors.Errorf("%s: %s", errResourceNotFound, yparser.GnmiPath2XPath(r.GetAbsolutePath(), false))
+		}
+		c, err := g.getController(r)
+		if err != nil {
+			return err
+		}
+		controllers = append(controllers, c)
+	}
+
+	jobs := make([]func() error, 0, len(controllers)+1)
+	for _, c := range controllers {
+		c := c
+		jobs = append(jobs, func() error {
+			return g.renderController(c)
+		})
+	}
+	jobs = append(jobs, func() error {
+		return g.renderControllersSetup(controllers)
+	})
+	return runJobs(g.GetConfig().GetJobs(), jobs)
+}
+
+func (g *Generator) renderController(c *Controller) error {
+	buf := new(bytes.Buffer)
+	if err := g.getTemplate().ExecuteTemplate(buf, "controller"+".tmpl", c); err != nil {
+		return err
+	}
+	b, err := formatSource(buf.Bytes())
+	if err != nil {
+		return errors.Wrapf(err, "%s, template controller.tmpl, controller %s", errFormatSource, c.Package)
+	}
+	return g.writeFile(renderKindControllers, filepath.Join(g.GetConfig().GetOutputDir(), controllersDir, c.Package, c.Package+".go"), b)
+}
+
+func (g *Generator) renderControllersSetup(controllers []*Controller) error {
+	s := struct {
+		Version     string
+		ApiGroup    string
+		Controllers []*Controller
+	}{
+		Version:     g.GetConfig().GetVersion(),
+		ApiGroup:    g.GetConfig().GetApiGroup(),
+		Controllers: controllers,
+	}
+	buf := new(bytes.Buffer)
+	if err := g.getTemplate().ExecuteTemplate(buf, "controllers"+".tmpl", s); err != nil {
+		return err
+	}
+	b, err := formatSource(buf.Bytes())
+	if err != nil {
+		return errors.Wrapf(err, "%s, template controllers.tmpl", errFormatSource)
+	}
+	return g.writeFile(renderKindControllers, filepath.Join(g.GetConfig().GetOutputDir(), controllersDir, "controllers.go"), b)
+}
+
+// getController returns the controller of the resource, the package of the controller
+// is named after the resource without prefix
+func (g *Generator) getController(r *resource.Resource) (*Controller, error) {
+	elems, err := g.getControllerPath(r)
+	if err != nil {
+		return nil, err
+	}
+	pkg := strings.ToLower(strcase.UpperCamelCase(r.GetResourceNameWithPrefix("")))
+	return &Controller{
+		Package:                pkg,
+		ImportPath:             path.Join(g.GetConfig().GetGoModule(), controllersDir, pkg),
+		APIPackage:             path.Join(g.GetConfig().GetGoModule(), "apis", g.GetConfig().GetVersion()),
+		Version:                g.GetConfig().GetVersion(),
+		ResourceName:           r.GetResourceNameWithPrefix(""),
+		ResourceNameWithPrefix: r.GetResourceNameWithPrefix(g.GetConfig().GetPrefix()),
+		Path:                   elems,
+	}, nil
+}
+
+// getControllerPath returns the gnmi path of the resource without the module. The keys
+// of the lists in the path are resolved from the yang tree, the keys of the resource are
+// read from the root struct of the resource and the keys of the parent resources from the
+// hierarchical keys in the parameters of the custom resource. The keys of the other lists
+// in the path are not part of the custom resource, hence the path cannot be rendered.
+func (g *Generator) getControllerPath(r *resource.Resource) ([]*ControllerPathElem, error) {
+	// the resources from the top level resource to the resource, the root resource
+	// of the generator is not part of the path
+	chain := make([]*resource.Resource, 0)
+	for p := r; p != nil && p.GetParent() != nil; p = p.GetParent() {
+		chain = append([]*resource.Resource{p}, chain...)
+	}
+	he := getHierarchicalElements(r)
+	resourceName := r.GetResourceNameWithPrefix(g.GetConfig().GetPrefix())
+	xpath := yparser.GnmiPath2XPath(r.GetAbsolutePath(), false)
+
+	elems := make([]*ControllerPathElem, 0)
+	var e *yang.Entry
+	for i, p := range chain {
+		pes := p.GetResourcePath().GetElem()
+		if i == 0 {
+			if len(pes) == 0 {
+				return nil, errors.Errorf("%s: %s", errResourceNotFound, xpath)
+			}
+			// the first element of a top level resource is the module
+			m, err := g.resolvePath(&gnmi.Path{Elem: pes[:1]})
+			if err != nil {
+				return nil, err
+			}
+			e, pes = m, pes[1:]
+		}
+		for j, pe := range pes {
+			if e = getChildEntry(e, pe.GetName()); e == nil {
+				return nil, errors.Errorf("%s: %s", errResourceNotFound, xpath)
+			}
+			elem := &ControllerPathElem{Name: pe.GetName()}
+			root := j == len(pes)-1
+			for _, k := range strings.Fields(e.Key) {
+				key := &ControllerPathKey{Name: k}
+				switch {
+				case root && p == r:
+					key.Value = "p." + resourceName + "." + strcase.UpperCamelCase(k)
+				case root:
+					for _, h := range he {
+						if re := p.GetRootContainerEntry(); re != nil && h.Name == re.GetName() && h.Key == k {
+							key.Value = "p." + strcase.UpperCamelCase(g.GetConfig().GetPrefix()) +
+								strcase.UpperCamelCase(h.Name) + strcase.UpperCamelCase(h.Key)
+						}
+					}
+				}
+				if key.Value == "" {
+					return nil, errors.Errorf("%s: resource %s, list %s, key %s", errControllerPathKey, xpath, pe.GetName(), k)
+				}
+				elem.Keys = append(elem.Keys, key)
+			}
+			elems = append(elems, elem)
+		}
+	}
+	return elems, nil
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yndd/ndd-yang/pkg/yparser"
+)
+
+// controllerPathString returns the path of the controller with the go expressions of the keys
+func controllerPathString(elems []*ControllerPathElem) string {
+	sb := strings.Builder{}
+	for _, elem := range elems {
+		sb.WriteString("/" + elem.Name)
+		for _, k := range elem.Keys {
+			sb.WriteString("[" + k.Name + "=" + k.Value + "]")
+		}
+	}
+	return sb.String()
+}
+
+func TestControllerPath(t *testing.T) {
+	cases := map[string]struct {
+		resourceMap string
+		want        map[string]string
+		wantErr     string
+	}{
+		"Hierarchy": {
+			resourceMap: `path:
+  /sample-interfaces/interfaces/interface:
+    excludes:
+      - /subinterface
+    hierarchy:
+      /subinterface:
+  /sample-network-instance/network-instances/network-instance:
+    excludes:
+      - /protocols
+    hierarchy:
+      /protocols/bgp:
+`,
+			want: map[string]string{
+				"/sample-interfaces/interfaces/interface":                                   "/interfaces/interface[name=p.SampleInterfacesInterface.Name]",
+				"/sample-interfaces/interfaces/interface/subinterface":                      "/interfaces/interface[name=p.SampleInterfaceName]/subinterface[index=p.SampleInterfacesInterfaceSubinterface.Index]",
+				"/sample-network-instance/network-instances/network-instance":               "/network-instances/network-instance[name=p.SampleNetworkinstancesNetworkinstance.Name]",
+				"/sample-network-instance/network-instances/network-instance/protocols/bgp": "/network-instances/network-instance[name=p.SampleNetworkInstanceName]/protocols/bgp",
+			},
+		},
+		"MultipleKeys": {
+			resourceMap: `path:
+  /sample-network-instance/network-instances/network-instance:
+    excludes:
+      - /interface
+    hierarchy:
+      /interface:
+`,
+			want: map[string]string{
+				"/sample-network-instance/network-instances/network-instance":           "/network-instances/network-instance[name=p.SampleNetworkinstancesNetworkinstance.Name]",
+				"/sample-network-instance/network-instances/network-instance/interface": "/network-instances/network-instance[name=p.SampleNetworkInstanceName]/interface[name=p.SampleNetworkinstancesNetworkinstanceInterface.Name][subinterface=p.SampleNetworkinstancesNetworkinstanceInterface.Subinterface]",
+			},
+		},
+		"TopLevelResourceInList": {
+			// the key of the network instance is not part of the custom resource
+			resourceMap: `path:
+  /sample-network-instance/network-instances/network-instance/protocols/bgp:
+`,
+			wantErr: errControllerPathKey + ": resource /sample-network-instance/network-instances/network-instance/protocols/bgp, list network-instance, key name",
+		},
+		"ChildResourceInList": {
+			// the subinterface is a top level resource in the list of interfaces
+			resourceMap: `path:
+  /sample-interfaces/interfaces/interface/subinterface:
+`,
+			wantErr: errControllerPathKey + ": resource /sample-interfaces/interfaces/interface/subinterface, list interface, key name",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			g := newSampleGenerator(t, tc.resourceMap)
+			err := g.RenderControllers()
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("got error %v, want %s", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := map[string]string{}
+			for _, r := range g.getRenderResources() {
+				elems, err := g.getControllerPath(r)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got[yparser.GnmiPath2XPath(r.GetAbsolutePath(), false)] = controllerPathString(elems)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got paths %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,431 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"bytes"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/stoewer/go-strcase"
+	"github.com/yndd/ndd-yang/pkg/container"
+	"github.com/yndd/ndd-yang/pkg/resource"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	errMarshalCRD = "cannot marshal crd"
+)
+
+// CustomResourceDefinition is the subset of the apiextensions.k8s.io/v1
+// CustomResourceDefinition that is rendered by the generator
+type CustomResourceDefinition struct {
+	APIVersion string      `yaml:"apiVersion"`
+	Kind       string      `yaml:"kind"`
+	Metadata   CRDMetadata `yaml:"metadata"`
+	Spec       CRDSpec     `yaml:"spec"`
+}
+
+type CRDMetadata struct {
+	Name string `yaml:"name"`
+}
+
+type CRDSpec struct {
+	Group    string       `yaml:"group"`
+	Names    CRDNames     `yaml:"names"`
+	Scope    string       `yaml:"scope"`
+	Versions []CRDVersion `yaml:"versions"`
+}
+
+type CRDNames struct {
+	Categories []string `yaml:"categories,omitempty"`
+	Kind       string   `yaml:"kind"`
+	ListKind   string   `yaml:"listKind"`
+	Plural     string   `yaml:"plural"`
+	Singular   string   `yaml:"singular"`
+}
+
+type CRDVersion struct {
+	AdditionalPrinterColumns []CRDPrinterColumn `yaml:"additionalPrinterColumns,omitempty"`
+	Name                     string             `yaml:"name"`
+	Schema                   CRDValidation      `yaml:"schema"`
+	Served                   bool               `yaml:"served"`
+	Storage                  bool               `yaml:"storage"`
+	Subresources             *CRDSubresources   `yaml:"subresources,omitempty"`
+}
+
+type CRDPrinterColumn struct {
+	JSONPath string `yaml:"jsonPath"`
+	Name     string `yaml:"name"`
+	Type     string `yaml:"type"`
+}
+
+type CRDSubresources struct {
+	Status map[string]interface{} `yaml:"status"`
+}
+
+type CRDValidation struct {
+	OpenAPIV3Schema *JSONSchemaProps `yaml:"openAPIV3Schema"`
+}
+
+// JSONSchemaProps is the subset of the OpenAPI v3 structural schema used in the CRDs
+type JSONSchemaProps struct {
+	Description            string                      `yaml:"description,omitempty"`
+	Type                   string                      `yaml:"type,omitempty"`
+	Format                 string                      `yaml:"format,omitempty"`
+	Default                interface{}                 `yaml:"default,omitempty"`
+	Enum                   []string                    `yaml:"enum,omitempty"`
+	Minimum                *int64                      `yaml:"minimum,omitempty"`
+	Maximum                *int64                      `yaml:"maximum,omitempty"`
+	MinLength              *int64                      `yaml:"minLength,omitempty"`
+	MaxLength              *int64                      `yaml:"maxLength,omitempty"`
+	Pattern                string                      `yaml:"pattern,omitempty"`
+	Items                  *JSONSchemaProps            `yaml:"items,omitempty"`
+	AdditionalProperties   *JSONSchemaProps            `yaml:"additionalProperties,omitempty"`
+	Properties             map[string]*JSONSchemaProps `yaml:"properties,omitempty"`
+	Required               []string                    `yaml:"required,omitempty"`
+	AllOf                  []*JSONSchemaProps          `yaml:"allOf,omitempty"`
+	AnyOf                  []*JSONSchemaProps          `yaml:"anyOf,omitempty"`
+	OneOf                  []*JSONSchemaProps          `yaml:"oneOf,omitempty"`
+	Not                    *JSONSchemaProps            `yaml:"not,omitempty"`
+	XKubernetesListType    string                      `yaml:"x-kubernetes-list-type,omitempty"`
+	XKubernetesListMapKeys []string                    `yaml:"x-kubernetes-list-map-keys,omitempty"`
+}
+
+// RenderCRDs writes a CustomResourceDefinition manifest per resource to
+// <crdOutputDir>/<apiGroup>_<plural>.yaml
+func (g *Generator) RenderCRDs() error {
+	g.startRender(renderKindCRDs)
+	dir := g.GetConfig().GetCrdOutputDir()
+	for _, r := range g.getRenderResources() {
+		if r.RootContainer == nil {
+			return errors.Errorf("%s: %s", errResourceNotFound, r.GetAbsoluteName())
+		}
+		crd := g.GetCRD(r)
+		b, err := marshalYAML(crd)
+		if err != nil {
+			return errors.Wrap(err, errMarshalCRD)
+		}
+		fileName := crd.Spec.Group + "_" + crd.Spec.Names.Plural + ".yaml"
+		if err := g.writeFile(renderKindCRDs, filepath.Join(dir, fileName), b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// marshalYAML marshals the crd or example as a yaml document with the indentation used by
+// kubernetes tooling
+func marshalYAML(v interface{}) ([]byte, error) {
+	buf := bytes.NewBufferString("---\n")
+	enc := yaml.NewEncoder(buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GetCRD returns the CustomResourceDefinition of the resource, the schema follows
+// the go types that are rendered with the resource templates
+func (g *Generator) GetCRD(r *resource.Resource) *CustomResourceDefinition {
+	kind := r.GetResourceNameWithPrefix(g.GetConfig().GetPrefix())
+	plural := strings.ToLower(kind) + "s"
+	return &CustomResourceDefinition{
+		APIVersion: "apiextensions.k8s.io/v1",
+		Kind:       "CustomResourceDefinition",
+		Metadata: CRDMetadata{
+			Name: plural + "." + g.GetConfig().GetApiGroup(),
+		},
+		Spec: CRDSpec{
+			Group: g.GetConfig().GetApiGroup(),
+			Names: CRDNames{
+				Categories: []string{"ndd", g.GetConfig().GetPrefix()},
+				Kind:       kind,
+				ListKind:   kind + "List",
+				Plural:     plural,
+				Singular:   strings.ToLower(kind),
+			},
+			Scope: "Cluster",
+			Versions: []CRDVersion{
+				{
+					AdditionalPrinterColumns: getPrinterColumns(),
+					Name:                     g.GetConfig().GetVersion(),
+					Schema: CRDValidation{
+						OpenAPIV3Schema: g.getResourceSchema(r, kind),
+					},
+					Served:       true,
+					Storage:      true,
+					Subresources: &CRDSubresources{Status: map[string]interface{}{}},
+				},
+			},
+		},
+	}
+}
+
+// getPrinterColumns returns the printer columns of the resourceEnd template
+func getPrinterColumns() []CRDPrinterColumn {
+	columns := []CRDPrinterColumn{}
+	for _, c := range []struct{ name, kind string }{
+		{"TARGET", "TargetFound"},
+		{"STATUS", "Ready"},
+		{"SYNC", "Synced"},
+		{"LOCALLEAFREF", "InternalLeafrefValidationSuccess"},
+		{"EXTLEAFREF", "ExternalLeafrefValidationSuccess"},
+		{"PARENTDEP", "ParentValidationSuccess"},
+	} {
+		columns = append(columns, CRDPrinterColumn{
+			JSONPath: ".status.conditions[?(@.kind=='" + c.kind + "')].status",
+			Name:     c.name,
+			Type:     "string",
+		})
+	}
+	return append(columns, CRDPrinterColumn{
+		JSONPath: ".metadata.creationTimestamp",
+		Name:     "AGE",
+		Type:     "date",
+	})
+}
+
+func (g *Generator) getResourceSchema(r *resource.Resource, kind string) *JSONSchemaProps {
+	// the parameters hold the keys of the parent resources and the root container of the resource
+	parameters := &JSONSchemaProps{
+		Type:       "object",
+		Properties: map[string]*JSONSchemaProps{},
+	}
+	for _, he := range getHierarchicalElements(r) {
+		name := strcase.KebabCase(he.Name) + "-" + strcase.KebabCase(he.Key)
+		parameters.Properties[name] = getLeafTypeSchema(he.Type)
+		parameters.Required = append(parameters.Required, name)
+	}
+	rootName := strcase.KebabCase(r.GetResourceNameWithPrefix(""))
+	parameters.Properties[rootName] = g.getContainerSchema(r.RootContainer)
+	parameters.Required = append(parameters.Required, rootName)
+
+	spec := getResourceSpecSchema()
+	spec.Description = "A " + kind + "Spec defines the desired state of a " + kind + "."
+	spec.Properties["forNetworkNode"] = parameters
+	spec.Required = append(spec.Required, "forNetworkNode")
+
+	status := getResourceStatusSchema()
+	status.Description = "A " + kind + "Status represents the observed state of a " + kind + "."
+	status.Properties["atNetworkNode"] = &JSONSchemaProps{Type: "object"}
+
+	return &JSONSchemaProps{
+		Description: kind + " is the Schema for the " + kind + " API",
+		Type:        "object",
+		Properties: map[string]*JSONSchemaProps{
+			"apiVersion": {Type: "string"},
+			"kind":       {Type: "string"},
+			"metadata":   {Type: "object"},
+			"spec":       spec,
+			"status":     status,
+		},
+	}
+}
+
+// getContainerSchema returns the schema of a container, following the resourceContainer template:
+// leaf-lists are arrays of values, entries with a next container are lists when they have a key
+// and objects otherwise.
+// The cases of the choices in the container are rendered as oneOf constraints
+func (g *Generator) getContainerSchema(c *container.Container) *JSONSchemaProps {
+	s := &JSONSchemaProps{
+		Type:       "object",
+		Properties: map[string]*JSONSchemaProps{},
+	}
+	for _, e := range c.GetEntries() {
+		name := strcase.KebabCase(e.GetName())
+		switch leaf := g.GetEntryLeafList(e); {
+		case leaf != nil:
+			items := getLeafSchema(leaf)
+			items.Default = nil
+			// the values of a leaf-list are unique
+			s.Properties[name] = &JSONSchemaProps{
+				Type:                "array",
+				Items:               items,
+				XKubernetesListType: "set",
+			}
+		case e.GetNext() != nil && len(e.GetKey()) > 0:
+			keys := make([]string, 0, len(e.GetKey()))
+			for _, k := range e.GetKey() {
+				keys = append(keys, strcase.KebabCase(k))
+			}
+			s.Properties[name] = &JSONSchemaProps{
+				Type:                   "array",
+				Items:                  g.getContainerSchema(e.GetNext()),
+				XKubernetesListType:    "map",
+				XKubernetesListMapKeys: keys,
+			}
+		case e.GetNext() != nil:
+			s.Properties[name] = g.getContainerSchema(e.GetNext())
+		default:
+			s.Properties[name] = getLeafSchema(e)
+			// the api server applies the defaults before the validation, a default in a case
+			// would select the case and conflict with the other cases of the choice
+			if len(g.GetEntryChoiceCases(e)) > 0 {
+				s.Properties[name].Default = nil
+			}
+		}
+		if e.GetMandatory() {
+			s.Required = append(s.Required, name)
+		}
+	}
+	// the cases of a choice are mutually exclusive
+	choices := g.GetContainerChoices(c)
+	switch len(choices) {
+	case 0:
+	case 1:
+		s.OneOf = getChoiceSchema(choices[0]).OneOf
+	default:
+		for _, ch := range choices {
+			s.AllOf = append(s.AllOf, getChoiceSchema(ch))
+		}
+	}
+	return s
+}
+
+// getLeafSchema returns the schema of a leaf with its validations
+func getLeafSchema(e *container.Entry) *JSONSchemaProps {
+	s := getLeafTypeSchema(e.GetType())
+	if len(e.GetEnum()) > 0 {
+		s.Enum = e.GetEnum()
+	}
+	if r := e.GetRange(); len(r) > 1 {
+		min, max := int64(r[0]), int64(r[len(r)-1])
+		s.Minimum, s.Maximum = &min, &max
+	}
+	if l := e.GetLength(); len(l) > 1 {
+		min, max := int64(l[0]), int64(l[len(l)-1])
+		s.MinLength, s.MaxLength = &min, &max
+	}
+	// the patterns of a union are alternatives for the member types which are not all strings
+	if len(e.GetPattern()) > 0 && !e.GetUnion() {
+		patterns := make([]string, 0, len(e.GetPattern()))
+		for _, p := range e.GetPattern() {
+			// yang patterns are implicitly anchored
+			patterns = append(patterns, "^("+p+")$")
+		}
+		s.Pattern = strings.Join(patterns, "|")
+	}
+	if d := e.GetDefault(); d != "" {
+		s.Default = getTypedValue(s.Type, d)
+	}
+	return s
+}
+
+// getLeafTypeSchema maps the go type of a leaf to the OpenAPI type
+func getLeafTypeSchema(t string) *JSONSchemaProps {
+	switch t {
+	case "bool":
+		return &JSONSchemaProps{Type: "boolean"}
+	case "int8", "int16", "int32", "uint8", "uint16":
+		return &JSONSchemaProps{Type: "integer", Format: "int32"}
+	case "int64", "uint32", "uint64":
+		return &JSONSchemaProps{Type: "integer", Format: "int64"}
+	default:
+		return &JSONSchemaProps{Type: "string"}
+	}
+}
+
+// getTypedValue converts the string value to the OpenAPI type, the string is returned
+// when it cannot be converted
+func getTypedValue(t, v string) interface{} {
+	switch t {
+	case "boolean":
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	case "integer":
+		if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return i
+		}
+	}
+	return v
+}
+
+// getResourceSpecSchema returns the schema of the ndd-runtime ResourceSpec
+func getResourceSpecSchema() *JSONSchemaProps {
+	return &JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]*JSONSchemaProps{
+			"active": {
+				Description: "Active specifies if the managed resource is active or not",
+				Type:        "boolean",
+				Default:     true,
+			},
+			"deletionPolicy": {
+				Description: "DeletionPolicy specifies what will happen to the underlying external when this managed resource is deleted - either \"Delete\" or \"Orphan\" the external resource.",
+				Type:        "string",
+				Default:     "Delete",
+				Enum:        []string{"Orphan", "Delete"},
+			},
+			"networkNodeRef": {
+				Description: "NetworkNodeReference specifies which network node will be used to create, observe, update, and delete this managed resource",
+				Type:        "object",
+				Default:     map[string]interface{}{"name": "default"},
+				Properties: map[string]*JSONSchemaProps{
+					"name": {
+						Description: "Name of the referenced object.",
+						Type:        "string",
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
+// getResourceStatusSchema returns the schema of the ndd-runtime ResourceStatus
+func getResourceStatusSchema() *JSONSchemaProps {
+	return &JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]*JSONSchemaProps{
+			"conditions": {
+				Description: "Conditions of the resource.",
+				Type:        "array",
+				Items: &JSONSchemaProps{
+					Type: "object",
+					Properties: map[string]*JSONSchemaProps{
+						"kind":               {Type: "string"},
+						"lastTransitionTime": {Type: "string", Format: "date-time"},
+						"message":            {Type: "string"},
+						"reason":             {Type: "string"},
+						"status":             {Type: "string"},
+					},
+					Required: []string{"kind", "lastTransitionTime", "reason", "status"},
+				},
+			},
+			"externalLeafRefs": {
+				Type:  "array",
+				Items: &JSONSchemaProps{Type: "string"},
+			},
+			"resourceIndexes": {
+				Type:                 "object",
+				AdditionalProperties: &JSONSchemaProps{Type: "string"},
+			},
+			"target": {
+				Type:  "array",
+				Items: &JSONSchemaProps{Type: "string"},
+			},
+		},
+	}
+}
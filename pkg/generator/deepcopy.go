@@ -0,0 +1,95 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"bytes"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/stoewer/go-strcase"
+	"github.com/yndd/ndd-yang/pkg/container"
+	"github.com/yndd/ndd-yang/pkg/yparser"
+)
+
+const (
+	// deepCopyFileName is the name of the file with the deepcopy functions, the
+	// name of the file controller-gen generates such that the file is replaced
+	deepCopyFileName = "zz_generated.deepcopy.go"
+)
+
+// DeepCopyContainer is a struct rendered for a container of a resource
+type DeepCopyContainer struct {
+	Name      string
+	Entries   []*container.Entry
+	LeafLists map[string]string // the go types of the values of the leaf-lists
+}
+
+// DeepCopyResource is a kubernetes api type rendered for a resource
+type DeepCopyResource struct {
+	Prefix                 string
+	ResourceLastElement    string
+	ResourceNameWithPrefix string
+	HElements              []*HeInfo
+}
+
+// RenderDeepCopy writes the DeepCopy, DeepCopyInto and DeepCopyObject functions of the
+// kubernetes api types of the rendered resources to <outputDir>/apis/<version>/zz_generated.deepcopy.go
+func (g *Generator) RenderDeepCopy() error {
+	g.startRender(renderKindDeepCopy)
+	if err := g.checkTypeNames(); err != nil {
+		return err
+	}
+	s := struct {
+		Version    string
+		Containers []*DeepCopyContainer
+		Resources  []*DeepCopyResource
+	}{
+		Version:    g.GetConfig().GetVersion(),
+		Containers: make([]*DeepCopyContainer, 0),
+		Resources:  make([]*DeepCopyResource, 0),
+	}
+	for _, r := range g.getRenderResources() {
+		if r.RootContainer == nil {
+			return errors.Errorf("%s: %s", errResourceNotFound, yparser.GnmiPath2XPath(r.GetAbsolutePath(), false))
+		}
+		for _, c := range g.getResourceContainers(r.RootContainer) {
+			s.Containers = append(s.Containers, &DeepCopyContainer{
+				Name:      c.GetFullName(),
+				Entries:   c.GetEntries(),
+				LeafLists: g.getLeafListTypes(c),
+			})
+		}
+		s.Resources = append(s.Resources, &DeepCopyResource{
+			Prefix:                 g.GetConfig().GetPrefix(),
+			ResourceLastElement:    strcase.UpperCamelCase(r.RootContainer.GetFullName()),
+			ResourceNameWithPrefix: r.GetResourceNameWithPrefix(g.GetConfig().GetPrefix()),
+			HElements:              getHierarchicalElements(r),
+		})
+	}
+
+	buf := new(bytes.Buffer)
+	if err := g.getTemplate().ExecuteTemplate(buf, "deepcopy"+".tmpl", s); err != nil {
+		return err
+	}
+	b, err := formatSource(buf.Bytes())
+	if err != nil {
+		return errors.Wrapf(err, "%s, template deepcopy.tmpl", errFormatSource)
+	}
+	dir := filepath.Join(g.GetConfig().GetOutputDir(), "apis", g.GetConfig().GetVersion())
+	return g.writeFile(renderKindDeepCopy, filepath.Join(dir, deepCopyFileName), b)
+}
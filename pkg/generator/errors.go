@@ -0,0 +1,58 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Errors collects the errors that occurred while processing the yang tree,
+// such that a single run reports every offending path
+type Errors []error
+
+func (e Errors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("%d errors occurred:", len(e)))
+	for _, err := range e {
+		sb.WriteString("\n\t* " + err.Error())
+	}
+	return sb.String()
+}
+
+// ErrorOrNil returns nil when no errors were collected
+func (e Errors) ErrorOrNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// appendError appends the error to the collection, nested collections are flattened
+func appendError(errs Errors, err error) Errors {
+	switch x := err.(type) {
+	case nil:
+		return errs
+	case Errors:
+		return append(errs, x...)
+	default:
+		return append(errs, err)
+	}
+}
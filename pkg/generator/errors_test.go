@@ -0,0 +1,144 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/pkg/errors"
+	"github.com/yndd/ndd-runtime/pkg/logging"
+	"github.com/yndd/ndd-yang/pkg/container"
+	"github.com/yndd/ndd-ygen/pkg/fsys"
+)
+
+func TestErrors(t *testing.T) {
+	err1 := errors.New("error 1")
+	err2 := errors.New("error 2")
+	err3 := errors.New("error 3")
+
+	cases := map[string]struct {
+		errs    []error
+		wantLen int
+		wantMsg string
+	}{
+		"None": {
+			errs:    []error{nil, nil},
+			wantLen: 0,
+		},
+		"Single": {
+			errs:    []error{nil, err1},
+			wantLen: 1,
+			wantMsg: "error 1",
+		},
+		"Multiple": {
+			errs:    []error{err1, nil, err2},
+			wantLen: 2,
+			wantMsg: "2 errors occurred:\n\t* error 1\n\t* error 2",
+		},
+		"Flatten": {
+			errs:    []error{err1, Errors{err2, err3}},
+			wantLen: 3,
+			wantMsg: "3 errors occurred:\n\t* error 1\n\t* error 2\n\t* error 3",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var errs Errors
+			for _, err := range tc.errs {
+				errs = appendError(errs, err)
+			}
+			if len(errs) != tc.wantLen {
+				t.Errorf("appendError: got %d errors, want %d", len(errs), tc.wantLen)
+			}
+			err := errs.ErrorOrNil()
+			if tc.wantLen == 0 {
+				if err != nil {
+					t.Errorf("ErrorOrNil: got %v, want nil", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("ErrorOrNil: got nil, want an error")
+			}
+			if err.Error() != tc.wantMsg {
+				t.Errorf("Error: got %q, want %q", err.Error(), tc.wantMsg)
+			}
+		})
+	}
+}
+
+func TestResourceGeneratorErrors(t *testing.T) {
+	inputFS := fsys.NewMemFS()
+	for name, data := range map[string]string{
+		"yang/test-system.yang": testSystemYang,
+		// both resources start at a leaf, the errors of both are reported
+		"leaf.yaml":   "path:\n  /test-system/system/server/counter:\n  /test-system/system/server/enabled:\n",
+		"server.yaml": "path:\n  /test-system/system/server:\n",
+	} {
+		if err := inputFS.WriteFile(name, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	newGenerator := func(mapFile string) *Generator {
+		g, err := NewGenerator(
+			WithInputFS(inputFS),
+			WithOutputFS(fsys.NewMemFS()),
+			WithLogging(logging.NewNopLogger()),
+			WithYangModuleDirs([]string{"yang"}),
+			WithResourceMapInputFile(mapFile),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return g
+	}
+
+	t.Run("RootLeaf", func(t *testing.T) {
+		err := newGenerator("leaf.yaml").Run()
+		errs, ok := err.(Errors)
+		if !ok {
+			t.Fatalf("Run: got %v, want Errors", err)
+		}
+		if len(errs) != 2 {
+			t.Fatalf("Run: got %d errors, want 2: %v", len(errs), errs)
+		}
+		for i, path := range []string{"/test-system/system/server/counter", "/test-system/system/server/enabled"} {
+			if want := path + ": " + errResourceRootLeaf; errs[i].Error() != want {
+				t.Errorf("Run: got %q, want %q", errs[i], want)
+			}
+		}
+	})
+
+	t.Run("ParentContainerNotFound", func(t *testing.T) {
+		g := newGenerator("server.yaml")
+		if err := g.Run(); err != nil {
+			t.Fatal(err)
+		}
+		// a leaf of the resource is processed while the root container of the resource
+		// was not created
+		r := g.getRenderResources()[0]
+		r.ContainerLevelKeys = map[int][]*container.Container{}
+		server := g.getEntries()[0].Dir["system"].Dir["server"]
+		path := &gnmi.Path{Elem: []*gnmi.PathElem{{Name: "test-system"}, {Name: "system"}, {Name: "server"}}}
+		err := g.ResourceGenerator("/test-system/system/server", path, server.Dir["name"], false, "", server.Namespace().Name)
+		if err == nil || !strings.HasSuffix(err.Error(), errParentContainerNotFound) {
+			t.Errorf("ResourceGenerator: got %v, want %q", err, errParentContainerNotFound)
+		}
+	})
+}
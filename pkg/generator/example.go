@@ -0,0 +1,300 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"path/filepath"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/stoewer/go-strcase"
+	"github.com/yndd/ndd-yang/pkg/container"
+	"github.com/yndd/ndd-yang/pkg/resource"
+)
+
+const (
+	errMarshalExample = "cannot marshal example"
+
+	// examplePlaceholder is the value of a string leaf without pattern
+	examplePlaceholder = "example"
+)
+
+// Example is an example custom resource of a resource
+type Example struct {
+	APIVersion string          `yaml:"apiVersion"`
+	Kind       string          `yaml:"kind"`
+	Metadata   ExampleMetadata `yaml:"metadata"`
+	Spec       ExampleSpec     `yaml:"spec"`
+}
+
+type ExampleMetadata struct {
+	Name string `yaml:"name"`
+}
+
+type ExampleSpec struct {
+	ForNetworkNode map[string]interface{} `yaml:"forNetworkNode"`
+}
+
+// RenderExamples writes an example custom resource per resource to
+// <exampleOutputDir>/<apiGroup>_<singular>.yaml
+func (g *Generator) RenderExamples() error {
+	g.startRender(renderKindExamples)
+	dir := g.GetConfig().GetExampleOutputDir()
+	for _, r := range g.getRenderResources() {
+		if r.RootContainer == nil {
+			return errors.Errorf("%s: %s", errResourceNotFound, r.GetAbsoluteName())
+		}
+		b, err := marshalYAML(g.GetExample(r))
+		if err != nil {
+			return errors.Wrap(err, errMarshalExample)
+		}
+		kind := r.GetResourceNameWithPrefix(g.GetConfig().GetPrefix())
+		fileName := g.GetConfig().GetApiGroup() + "_" + strings.ToLower(kind) + ".yaml"
+		if err := g.writeFile(renderKindExamples, filepath.Join(dir, fileName), b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetExample returns an example custom resource of the resource that is accepted by the
+// schema of the CRD. The keys and the mandatory leaves are set with a placeholder that
+// conforms to the type of the leaf and the leaves with a default are set to the default.
+// The lists are only populated when they are mandatory and only the first case, in name
+// order, of a choice is populated.
+func (g *Generator) GetExample(r *resource.Resource) *Example {
+	kind := r.GetResourceNameWithPrefix(g.GetConfig().GetPrefix())
+	parameters := map[string]interface{}{}
+	// the keys of the parent resources get the placeholders of the parent examples
+	for p := r.GetParent(); p != nil && p.GetParent() != nil; p = p.GetParent() {
+		e := p.GetRootContainerEntry()
+		if e == nil || p.RootContainer == nil {
+			continue
+		}
+		for _, ke := range p.RootContainer.GetEntries() {
+			if ke.GetName() == e.Key {
+				parameters[strcase.KebabCase(e.GetName())+"-"+strcase.KebabCase(e.Key)] = g.getExampleLeafValue(ke)
+			}
+		}
+	}
+	parameters[strcase.KebabCase(r.GetResourceNameWithPrefix(""))] = g.getExampleContainer(r.RootContainer)
+
+	return &Example{
+		APIVersion: g.GetConfig().GetApiGroup() + "/" + g.GetConfig().GetVersion(),
+		Kind:       kind,
+		Metadata: ExampleMetadata{
+			Name: strcase.KebabCase(kind) + "-example",
+		},
+		Spec: ExampleSpec{
+			ForNetworkNode: parameters,
+		},
+	}
+}
+
+// getExampleContainer returns the example object of the container
+func (g *Generator) getExampleContainer(c *container.Container) map[string]interface{} {
+	// the first case of every choice is selected, the cases are sorted by name
+	selected := map[string]string{}
+	choices := g.GetContainerChoices(c)
+	for _, ch := range choices {
+		selected[ch.Name] = ch.Cases[0].Name
+	}
+
+	o := map[string]interface{}{}
+	for _, e := range c.GetEntries() {
+		if !isSelectedCase(g.GetEntryChoiceCases(e), selected) {
+			continue
+		}
+		if v, ok := g.getExampleEntry(e, e.GetMandatory()); ok {
+			o[strcase.KebabCase(e.GetName())] = v
+		}
+	}
+	// a mandatory choice needs a value in the selected case
+	for _, ch := range choices {
+		if !ch.Mandatory || len(ch.Cases[0].Entries) == 0 {
+			continue
+		}
+		set := false
+		for _, name := range ch.Cases[0].Entries {
+			_, ok := o[strcase.KebabCase(name)]
+			set = set || ok
+		}
+		if set {
+			continue
+		}
+		for _, e := range c.GetEntries() {
+			if e.GetName() == ch.Cases[0].Entries[0] {
+				v, _ := g.getExampleEntry(e, true)
+				o[strcase.KebabCase(e.GetName())] = v
+			}
+		}
+	}
+	return o
+}
+
+// getExampleEntry returns the example value of the entry, the value is only returned when
+// the entry is required or the entry has a value
+func (g *Generator) getExampleEntry(e *container.Entry, required bool) (interface{}, bool) {
+	switch leaf := g.GetEntryLeafList(e); {
+	case leaf != nil:
+		if v, ok := g.getExampleEntry(leaf, required || (e.GetListAttr() != nil && e.GetListAttr().MinElements > 0)); ok {
+			return []interface{}{v}, true
+		}
+		return nil, false
+	case e.GetNext() != nil && len(e.GetKey()) > 0:
+		if !required && (e.GetListAttr() == nil || e.GetListAttr().MinElements == 0) {
+			return nil, false
+		}
+		return []interface{}{g.getExampleContainer(e.GetNext())}, true
+	case e.GetNext() != nil:
+		o := g.getExampleContainer(e.GetNext())
+		return o, required || len(o) > 0
+	case e.GetDefault() != "":
+		return getTypedValue(getLeafTypeSchema(e.GetType()).Type, e.GetDefault()), true
+	case required || e.GetKeyBool():
+		return g.getExampleLeafValue(e), true
+	}
+	return nil, false
+}
+
+// getExampleLeafValue returns a placeholder for the value of the leaf that conforms to the
+// type, enumeration, identities, range, length and pattern of the leaf
+func (g *Generator) getExampleLeafValue(e *container.Entry) interface{} {
+	switch getLeafTypeSchema(e.GetType()).Type {
+	case "boolean":
+		return false
+	case "integer":
+		if r := e.GetRange(); len(r) > 0 {
+			return int64(r[0])
+		}
+		return int64(0)
+	}
+	if enum := e.GetEnum(); len(enum) > 0 {
+		return enum[0]
+	}
+	if t, ok := g.entryYangTypes[e]; ok {
+		// the qualified identities keep the module in the value of the field
+		if identities := getIdentities(t); len(identities) > 0 {
+			if identities[0].Qualified {
+				return identities[0].Module + ":" + identities[0].Name
+			}
+			return identities[0].Name
+		}
+	}
+	if !e.GetUnion() {
+		for _, p := range e.GetPattern() {
+			if s, ok := getPatternExample(p, e.GetLength()); ok {
+				return s
+			}
+		}
+	}
+	return getLengthExample(examplePlaceholder, e.GetLength())
+}
+
+// isSelectedCase returns true if the cases of the entry are the selected cases of the choices
+func isSelectedCase(cc []*ChoiceCase, selected map[string]string) bool {
+	for _, c := range cc {
+		if selected[c.Choice] != c.Case {
+			return false
+		}
+	}
+	return true
+}
+
+// getLengthExample pads or truncates the string to the length range
+func getLengthExample(s string, length []int) string {
+	if len(length) < 2 {
+		return s
+	}
+	min, max := length[0], length[len(length)-1]
+	if len(s) < min {
+		s += strings.Repeat("x", min-len(s))
+	}
+	if max >= min && len(s) > max {
+		s = s[:max]
+	}
+	return s
+}
+
+// getPatternExample returns the shortest string that matches the yang pattern, with the
+// preference for the first alternatives, and that is within the length range
+func getPatternExample(pattern string, length []int) (string, bool) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", false
+	}
+	var b strings.Builder
+	writePatternExample(&b, re.Simplify())
+	s := b.String()
+	if len(length) > 1 && (len(s) < length[0] || len(s) > length[len(length)-1]) {
+		return "", false
+	}
+	// yang patterns are implicitly anchored
+	if ok, err := regexp.MatchString("^(?:"+pattern+")$", s); err != nil || !ok {
+		return "", false
+	}
+	return s, true
+}
+
+func writePatternExample(b *strings.Builder, re *syntax.Regexp) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		b.WriteString(string(re.Rune))
+	case syntax.OpCharClass:
+		if len(re.Rune) > 0 {
+			b.WriteRune(getCharClassExample(re.Rune))
+		}
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		b.WriteRune('a')
+	case syntax.OpCapture, syntax.OpPlus:
+		writePatternExample(b, re.Sub[0])
+	case syntax.OpRepeat:
+		for i := 0; i < re.Min; i++ {
+			writePatternExample(b, re.Sub[0])
+		}
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			writePatternExample(b, sub)
+		}
+	case syntax.OpAlternate:
+		writePatternExample(b, re.Sub[0])
+	}
+}
+
+// getCharClassExample returns a character of the character class, alphanumeric characters
+// are preferred. The ranges of the class are pairs of the first and last character.
+func getCharClassExample(ranges []rune) rune {
+	for _, preferred := range []rune{'a', '0', 'A', '-'} {
+		for i := 0; i+1 < len(ranges); i += 2 {
+			if ranges[i] <= preferred && preferred <= ranges[i+1] {
+				return preferred
+			}
+		}
+	}
+	// the first printable character that is not a space
+	for i := 0; i+1 < len(ranges); i += 2 {
+		if ranges[i+1] > ' ' {
+			if ranges[i] <= ' ' {
+				return '!'
+			}
+			return ranges[i]
+		}
+	}
+	return ranges[0]
+}
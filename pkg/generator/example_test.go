@@ -0,0 +1,360 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/yndd/ndd-runtime/pkg/logging"
+	"github.com/yndd/ndd-ygen/pkg/fsys"
+	"gopkg.in/yaml.v3"
+)
+
+const testChoiceYang = `module test-choice {
+  namespace "urn:test:choice";
+  prefix tc;
+
+  container routes {
+    list route {
+      key "prefix";
+      leaf prefix {
+        type string {
+          pattern '([0-9]{1,3}\.){3}[0-9]{1,3}/[0-9]{1,2}';
+        }
+      }
+      choice next-hop {
+        mandatory true;
+        case interface {
+          leaf interface {
+            type string {
+              length "3..8";
+            }
+            default "lo0";
+          }
+        }
+        case address {
+          leaf address {
+            type string;
+            default "0.0.0.0";
+          }
+        }
+      }
+      list label {
+        key "value";
+        min-elements 1;
+        leaf value {
+          type uint32 {
+            range "16..1048575";
+          }
+        }
+      }
+    }
+  }
+}
+`
+
+func TestGetPatternExample(t *testing.T) {
+	cases := map[string]struct {
+		pattern string
+		length  []int
+		want    string
+		wantOK  bool
+	}{
+		"Alternate": {
+			pattern: `(ethernet-[0-9]+/[0-9]+|lo[0-9]+)`,
+			want:    "ethernet-0/0",
+			wantOK:  true,
+		},
+		"Repeat": {
+			pattern: `([0-9]{1,3}\.){3}[0-9]{1,3}`,
+			want:    "0.0.0.0",
+			wantOK:  true,
+		},
+		"CharClass": {
+			pattern: `[A-Z][a-z]*`,
+			want:    "A",
+			wantOK:  true,
+		},
+		"Length": {
+			pattern: `[a-z]+`,
+			length:  []int{3, 8},
+			wantOK:  false,
+		},
+		"Invalid": {
+			pattern: `[a-z`,
+			wantOK:  false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, ok := getPatternExample(tc.pattern, tc.length)
+			if ok != tc.wantOK || got != tc.want {
+				t.Errorf("getPatternExample(%q): got %q, %t, want %q, %t", tc.pattern, got, ok, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
+
+// TestExamplesMatchCRDs validates the examples of the resources against the schema of the CRDs
+func TestExamplesMatchCRDs(t *testing.T) {
+	inputFS := fsys.NewMemFS()
+	for name, data := range map[string]string{
+		"yang/test-system.yang":     testSystemYang,
+		"yang/test-system-ext.yang": testSystemExtYang,
+		"yang/test-choice.yang":     testChoiceYang,
+		"map.yaml":                  "path:\n  /test-system/system/server:\n  /test-choice/routes/route:\n",
+	} {
+		if err := inputFS.WriteFile(name, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cases := map[string][]Option{
+		"Inline": {
+			WithInputFS(inputFS),
+			WithYangModuleDirs([]string{"yang"}),
+			WithResourceMapInputFile("map.yaml"),
+		},
+		"Testdata": {
+			WithYangImportDirs([]string{filepath.Join(testdataDir, "yang")}),
+			WithYangModuleDirs([]string{filepath.Join(testdataDir, "yang")}),
+			WithResourceMapInputFile(filepath.Join(testdataDir, "resources", "map.yaml")),
+		},
+	}
+	for name, opts := range cases {
+		g, err := NewGenerator(append([]Option{
+			WithOutputFS(fsys.NewMemFS()),
+			WithLogging(logging.NewNopLogger()),
+			WithVersion("v1alpha1"),
+			WithAPIGroup("test.ndd.yndd.io"),
+			WithPrefix("test"),
+		}, opts...)...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := g.Run(); err != nil {
+			t.Fatal(err)
+		}
+		for _, r := range g.getRenderResources() {
+			b, err := marshalYAML(g.GetExample(r))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var example interface{}
+			if err := yaml.Unmarshal(b, &example); err != nil {
+				t.Fatal(err)
+			}
+			// the api server applies the defaults of the schema before the validation
+			schema := g.GetCRD(r).Spec.Versions[0].Schema.OpenAPIV3Schema
+			applySchemaDefaults(example, schema)
+			for _, err := range validateSchema("", example, schema) {
+				t.Errorf("%s: %s: %s\n%s", name, r.GetResourceNameWithPrefix("test"), err, b)
+			}
+		}
+	}
+}
+
+// applySchemaDefaults sets the defaults of the properties that are not set in the value
+func applySchemaDefaults(v interface{}, s *JSONSchemaProps) {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		for name, ps := range s.Properties {
+			if _, ok := x[name]; !ok && ps.Default != nil {
+				// the integers are decoded as int
+				if i, ok := ps.Default.(int64); ok {
+					x[name] = int(i)
+				} else {
+					x[name] = ps.Default
+				}
+			}
+			if pv, ok := x[name]; ok {
+				applySchemaDefaults(pv, ps)
+			}
+		}
+	case []interface{}:
+		for _, iv := range x {
+			if s.Items != nil {
+				applySchemaDefaults(iv, s.Items)
+			}
+		}
+	}
+}
+
+// validateSchema returns the violations of the schema by the value, the properties
+// that are not in the schema are violations since they are pruned by kubernetes
+func validateSchema(path string, v interface{}, s *JSONSchemaProps) []string {
+	var errs []string
+	fail := func(format string, args ...interface{}) []string {
+		return append(errs, path+": "+fmt.Sprintf(format, args...))
+	}
+	switch s.Type {
+	case "object":
+		o, ok := v.(map[string]interface{})
+		if !ok {
+			return fail("expected an object, got %v", v)
+		}
+		for _, name := range s.Required {
+			if _, ok := o[name]; !ok {
+				errs = fail("missing required property %s", name)
+			}
+		}
+		if s.Properties != nil {
+			for name, pv := range o {
+				ps, ok := s.Properties[name]
+				if !ok {
+					errs = fail("unknown property %s", name)
+					continue
+				}
+				errs = append(errs, validateSchema(path+"."+name, pv, ps)...)
+			}
+		}
+	case "array":
+		a, ok := v.([]interface{})
+		if !ok {
+			return fail("expected an array, got %v", v)
+		}
+		for i, iv := range a {
+			errs = append(errs, validateSchema(fmt.Sprintf("%s[%d]", path, i), iv, s.Items)...)
+		}
+	case "integer":
+		i, ok := v.(int)
+		if !ok {
+			return fail("expected an integer, got %v", v)
+		}
+		if s.Minimum != nil && int64(i) < *s.Minimum || s.Maximum != nil && int64(i) > *s.Maximum {
+			errs = fail("%d is out of range", i)
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			return fail("expected a boolean, got %v", v)
+		}
+	case "string":
+		str, ok := v.(string)
+		if !ok {
+			return fail("expected a string, got %v", v)
+		}
+		if s.MinLength != nil && int64(len(str)) < *s.MinLength || s.MaxLength != nil && int64(len(str)) > *s.MaxLength {
+			errs = fail("the length of %q is out of range", str)
+		}
+		if s.Pattern != "" && !regexp.MustCompile(s.Pattern).MatchString(str) {
+			errs = fail("%q does not match %s", str, s.Pattern)
+		}
+		if len(s.Enum) > 0 {
+			found := false
+			for _, e := range s.Enum {
+				found = found || e == str
+			}
+			if !found {
+				errs = fail("%q is not one of %v", str, s.Enum)
+			}
+		}
+	}
+	// the choices are validated without the properties of the sub schemas
+	matches := func(s *JSONSchemaProps) bool {
+		return len(validateSchema(path, v, &JSONSchemaProps{
+			Required: s.Required, AnyOf: s.AnyOf, OneOf: s.OneOf, AllOf: s.AllOf, Not: s.Not, Type: "object",
+		})) == 0
+	}
+	if len(s.OneOf) > 0 {
+		n := 0
+		for _, sub := range s.OneOf {
+			if matches(sub) {
+				n++
+			}
+		}
+		if n != 1 {
+			errs = fail("matches %d instead of one of the oneOf schemas", n)
+		}
+	}
+	if len(s.AnyOf) > 0 {
+		n := 0
+		for _, sub := range s.AnyOf {
+			if matches(sub) {
+				n++
+			}
+		}
+		if n == 0 {
+			errs = fail("matches none of the anyOf schemas")
+		}
+	}
+	for _, sub := range s.AllOf {
+		if !matches(sub) {
+			errs = fail("does not match the allOf schemas")
+		}
+	}
+	if s.Not != nil && matches(s.Not) {
+		errs = fail("matches the not schema")
+	}
+	return errs
+}
+
+func TestExampleIdentityValue(t *testing.T) {
+	cases := map[string]struct {
+		alt  bool
+		want string
+	}{
+		// the name of the identity is unique
+		"Unqualified": {want: "ssh"},
+		// test-system-alt defines the ssh identity as well
+		"Qualified": {alt: true, want: "test-system:ssh"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			inputFS := fsys.NewMemFS()
+			files := map[string]string{
+				"yang/test-system.yang": testSystemYang,
+				"map.yaml":              "path:\n  /test-system/system/server:\n",
+			}
+			if tc.alt {
+				files["yang/test-system-alt.yang"] = testSystemAltYang
+			}
+			for name, data := range files {
+				if err := inputFS.WriteFile(name, []byte(data), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			g, err := NewGenerator(
+				WithInputFS(inputFS),
+				WithOutputFS(fsys.NewMemFS()),
+				WithLogging(logging.NewNopLogger()),
+				WithYangModuleDirs([]string{"yang"}),
+				WithResourceMapInputFile("map.yaml"),
+				WithVersion("v1alpha1"),
+				WithAPIGroup("test.ndd.yndd.io"),
+				WithPrefix("test"),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := g.Run(); err != nil {
+				t.Fatal(err)
+			}
+			for _, e := range g.getRenderResources()[0].RootContainer.GetEntries() {
+				if e.GetName() != "protocol" {
+					continue
+				}
+				if got := g.getExampleLeafValue(e); got != tc.want {
+					t.Errorf("got %v, want %s", got, tc.want)
+				}
+				return
+			}
+			t.Fatal("the protocol leaf is not found")
+		})
+	}
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/pkg/errors"
+)
+
+const (
+	errInvalidFeature          = "invalid feature, expected module:feature"
+	errInvalidIfFeature        = "invalid if-feature expression"
+	errIfFeaturePrefixNotFound = "cannot resolve if-feature prefix"
+)
+
+// enabledFeatures holds the enabled features per module name
+type enabledFeatures map[string]map[string]bool
+
+// parseFeatures parses a list of module:feature strings
+func parseFeatures(features []string) (enabledFeatures, error) {
+	ef := enabledFeatures{}
+	for _, f := range features {
+		split := strings.Split(strings.TrimSpace(f), ":")
+		if len(split) != 2 || split[0] == "" || split[1] == "" {
+			return nil, errors.Wrap(errors.New(errInvalidFeature), f)
+		}
+		if _, ok := ef[split[0]]; !ok {
+			ef[split[0]] = map[string]bool{}
+		}
+		ef[split[0]][split[1]] = true
+	}
+	return ef, nil
+}
+
+// pruneFeatures removes the children of the entry, recursively, for which the
+// if-feature statements are not satisfied by the enabled features
+func (ef enabledFeatures) pruneFeatures(e *yang.Entry) error {
+	// sort the names to return the errors in a consistent order
+	names := make([]string, 0, len(e.Dir))
+	for name := range e.Dir {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs Errors
+	for _, name := range names {
+		child := e.Dir[name]
+		enabled, err := ef.isEnabled(child)
+		if err != nil {
+			errs = appendError(errs, errors.Wrap(err, child.Path()))
+			continue
+		}
+		if !enabled {
+			delete(e.Dir, name)
+			continue
+		}
+		if err := ef.pruneFeatures(child); err != nil {
+			errs = appendError(errs, err)
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+// isEnabled returns true if all if-feature statements of the entry evaluate to true
+func (ef enabledFeatures) isEnabled(e *yang.Entry) (bool, error) {
+	for _, ifFeature := range e.Extra["if-feature"] {
+		v, ok := ifFeature.(*yang.Value)
+		if !ok {
+			continue
+		}
+		n := v.Parent
+		if n == nil {
+			n = e.Node
+		}
+		p := &ifFeatureParser{
+			tokens:   tokenizeIfFeature(v.Name),
+			node:     n,
+			features: ef,
+		}
+		enabled, err := p.parseOr()
+		if err != nil {
+			return false, errors.Wrapf(err, "%s %q", errInvalidIfFeature, v.Name)
+		}
+		if p.pos != len(p.tokens) {
+			return false, errors.Errorf("%s %q", errInvalidIfFeature, v.Name)
+		}
+		if !enabled {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// tokenizeIfFeature splits an if-feature expression in parenthesis and words
+func tokenizeIfFeature(s string) []string {
+	s = strings.ReplaceAll(s, "(", " ( ")
+	s = strings.ReplaceAll(s, ")", " ) ")
+	return strings.Fields(s)
+}
+
+// ifFeatureParser evaluates an if-feature expression as defined in RFC7950 section 7.20.2
+//
+//	if-feature-expr   = if-feature-term [or if-feature-expr]
+//	if-feature-term   = if-feature-factor [and if-feature-term]
+//	if-feature-factor = not if-feature-factor / ( if-feature-expr ) / identifier-ref
+type ifFeatureParser struct {
+	tokens   []string
+	pos      int
+	node     yang.Node
+	features enabledFeatures
+}
+
+func (p *ifFeatureParser) next() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	t := p.tokens[p.pos]
+	p.pos++
+	return t
+}
+
+func (p *ifFeatureParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *ifFeatureParser) parseOr() (bool, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return false, err
+	}
+	for p.peek() == "or" {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return false, err
+		}
+		left = left || right
+	}
+	return left, nil
+}
+
+func (p *ifFeatureParser) parseAnd() (bool, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return false, err
+	}
+	for p.peek() == "and" {
+		p.next()
+		right, err := p.parseFactor()
+		if err != nil {
+			return false, err
+		}
+		left = left && right
+	}
+	return left, nil
+}
+
+func (p *ifFeatureParser) parseFactor() (bool, error) {
+	switch t := p.next(); t {
+	case "not":
+		v, err := p.parseFactor()
+		return !v, err
+	case "(":
+		v, err := p.parseOr()
+		if err != nil {
+			return false, err
+		}
+		if p.next() != ")" {
+			return false, errors.New("missing closing parenthesis")
+		}
+		return v, nil
+	case "", ")", "and", "or":
+		return false, errors.Errorf("unexpected token %q", t)
+	default:
+		return p.isFeatureEnabled(t)
+	}
+}
+
+// isFeatureEnabled resolves the prefix of the feature relative to the module
+// the if-feature statement is defined in and checks if the feature is enabled
+func (p *ifFeatureParser) isFeatureEnabled(ref string) (bool, error) {
+	prefix, feature := "", ref
+	if split := strings.SplitN(ref, ":", 2); len(split) == 2 {
+		prefix, feature = split[0], split[1]
+	}
+	m := yang.FindModuleByPrefix(p.node, prefix)
+	if m == nil {
+		return false, errors.Wrap(errors.New(errIfFeaturePrefixNotFound), ref)
+	}
+	moduleName := m.Name
+	if m.Kind() == "submodule" && m.BelongsTo != nil {
+		moduleName = m.BelongsTo.Name
+	}
+	return p.features[moduleName][feature], nil
+}
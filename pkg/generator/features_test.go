@@ -0,0 +1,337 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/yndd/ndd-runtime/pkg/logging"
+	"github.com/yndd/ndd-ygen/pkg/fsys"
+)
+
+const testFeatureTypesYang = `module test-feature-types {
+  namespace "urn:test:feature-types";
+  prefix tft;
+
+  feature remote;
+}
+`
+
+func TestIfFeature(t *testing.T) {
+	cases := map[string]struct {
+		expr     string
+		features []string
+		want     bool
+		wantErr  string
+	}{
+		"Enabled": {
+			expr:     "a",
+			features: []string{"test-if-feature:a"},
+			want:     true,
+		},
+		"Disabled": {
+			expr:     "a",
+			features: []string{"test-if-feature:b"},
+			want:     false,
+		},
+		"Not": {
+			expr: "not a",
+			want: true,
+		},
+		// a or (b and c)
+		"AndBindsTighterThanOr": {
+			expr:     "a or b and c",
+			features: []string{"test-if-feature:a"},
+			want:     true,
+		},
+		"AndBindsTighterThanOrFalse": {
+			expr:     "a or b and c",
+			features: []string{"test-if-feature:b"},
+			want:     false,
+		},
+		// (not a) and b
+		"NotBindsTighterThanAnd": {
+			expr: "not a and b",
+			want: false,
+		},
+		"NotBindsTighterThanAndTrue": {
+			expr:     "not a and b",
+			features: []string{"test-if-feature:b"},
+			want:     true,
+		},
+		"Parentheses": {
+			expr:     "(a or b) and c",
+			features: []string{"test-if-feature:a"},
+			want:     false,
+		},
+		"ParenthesesTrue": {
+			expr:     "(a or b) and c",
+			features: []string{"test-if-feature:b", "test-if-feature:c"},
+			want:     true,
+		},
+		"NestedParentheses": {
+			expr:     "not (a and (b or c))",
+			features: []string{"test-if-feature:a", "test-if-feature:c"},
+			want:     false,
+		},
+		"PrefixOfImport": {
+			expr:     "tft:remote",
+			features: []string{"test-feature-types:remote"},
+			want:     true,
+		},
+		"PrefixOfImportOtherModule": {
+			expr:     "tft:remote",
+			features: []string{"test-if-feature:remote"},
+			want:     false,
+		},
+		"PrefixOfModule": {
+			expr:     "tif:a",
+			features: []string{"test-if-feature:a"},
+			want:     true,
+		},
+		"UnknownPrefix": {
+			expr:    "unknown:a",
+			wantErr: errIfFeaturePrefixNotFound,
+		},
+		"MissingOperand": {
+			expr:    "a and",
+			wantErr: errInvalidIfFeature,
+		},
+		"MissingClosingParenthesis": {
+			expr:    "(a or b",
+			wantErr: "missing closing parenthesis",
+		},
+		"TrailingToken": {
+			expr:    "a b",
+			wantErr: errInvalidIfFeature,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ms := yang.NewModules()
+			if err := ms.Parse(testFeatureTypesYang, "test-feature-types.yang"); err != nil {
+				t.Fatal(err)
+			}
+			if err := ms.Parse(`module test-if-feature {
+  namespace "urn:test:if-feature";
+  prefix tif;
+  import test-feature-types { prefix tft; }
+
+  feature a;
+  feature b;
+  feature c;
+
+  leaf x {
+    if-feature "`+tc.expr+`";
+    type string;
+  }
+}
+`, "test-if-feature.yang"); err != nil {
+				t.Fatal(err)
+			}
+			if errs := ms.Process(); len(errs) > 0 {
+				t.Fatal(errs)
+			}
+			ef, err := parseFeatures(tc.features)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := ef.isEnabled(yang.ToEntry(ms.Modules["test-if-feature"]).Dir["x"])
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("%q: got %t, want %t", tc.expr, got, tc.want)
+			}
+		})
+	}
+}
+
+const testFeaturesYang = `module test-features {
+  namespace "urn:test:features";
+  prefix tf;
+
+  feature ntp;
+  feature syslog;
+
+  container system {
+    list server {
+      key "name";
+      leaf name {
+        type string;
+      }
+      leaf ntp-server {
+        if-feature ntp;
+        type string;
+      }
+      container syslog {
+        if-feature "syslog and not ntp";
+        leaf host {
+          type string;
+        }
+      }
+      leaf mtu {
+        type uint16;
+      }
+      leaf description {
+        type string;
+      }
+    }
+  }
+}
+`
+
+const testFeaturesDeviationYang = `module test-features-deviation {
+  namespace "urn:test:features-deviation";
+  prefix tfd;
+
+  import test-features {
+    prefix tf;
+  }
+
+  deviation /tf:system/tf:server/tf:description {
+    deviate not-supported;
+  }
+
+  deviation /tf:system/tf:server/tf:mtu {
+    deviate replace {
+      type uint32;
+    }
+  }
+}
+`
+
+func TestFeaturesAndDeviations(t *testing.T) {
+	inputFS := fsys.NewMemFS()
+	for name, data := range map[string]string{
+		"yang/test-features.yang":                testFeaturesYang,
+		"deviation/test-features-deviation.yang": testFeaturesDeviationYang,
+		"map.yaml":                               "path:\n  /test-features/system/server:\n",
+	} {
+		if err := inputFS.WriteFile(name, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cases := map[string]struct {
+		features   []string
+		deviations []string
+		want       []string
+		wantNot    []string
+		wantErr    string
+	}{
+		// the if-feature statements are only evaluated when features are enabled
+		"NoFeatures": {
+			want: []string{"NtpServer *string", "Syslog *ServerSyslog", "Mtu *uint16", "Description *string"},
+		},
+		"PruneLeaf": {
+			features: []string{"test-features:syslog"},
+			want:     []string{"Syslog *ServerSyslog", "type ServerSyslog struct"},
+			wantNot:  []string{"NtpServer"},
+		},
+		"PruneContainer": {
+			features: []string{"test-features:ntp", "test-features:syslog"},
+			want:     []string{"NtpServer *string"},
+			wantNot:  []string{"Syslog", "Host"},
+		},
+		"Deviations": {
+			deviations: []string{"deviation"},
+			want:       []string{"Mtu *uint32", "NtpServer *string"},
+			wantNot:    []string{"Description", "Mtu *uint16"},
+		},
+		"FeaturesAndDeviations": {
+			features:   []string{"test-features:ntp"},
+			deviations: []string{"deviation"},
+			want:       []string{"NtpServer *string", "Mtu *uint32"},
+			wantNot:    []string{"Syslog", "Description"},
+		},
+		"NoFeatureEnabled": {
+			features: []string{},
+			want:     []string{"Mtu *uint16"},
+			wantNot:  []string{"NtpServer", "Syslog"},
+		},
+		"InvalidFeature": {
+			features: []string{"ntp"},
+			wantErr:  errInvalidFeature,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			outputFS := fsys.NewMemFS()
+			opts := []Option{
+				WithInputFS(inputFS),
+				WithOutputFS(outputFS),
+				WithLogging(logging.NewNopLogger()),
+				WithYangModuleDirs([]string{"yang"}),
+				WithDeviationModules(tc.deviations),
+				WithResourceMapInputFile("map.yaml"),
+				WithOutputDir(testOutputDir),
+				WithVersion("v1alpha1"),
+				WithAPIGroup("test.ndd.yndd.io"),
+				WithPrefix("test"),
+				WithLocalRender(true),
+			}
+			// the features are set like the --features flag, only when specified
+			if tc.features != nil {
+				opts = append(opts, WithFeatures(tc.features))
+			}
+			g, err := NewGenerator(opts...)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := g.Run(); err != nil {
+				t.Fatal(err)
+			}
+			if err := g.Render(); err != nil {
+				t.Fatal(err)
+			}
+			b, err := outputFS.ReadFile("out/apis/v1alpha1/test_system_server_types.go")
+			if err != nil {
+				t.Fatal(err)
+			}
+			// the fields are aligned by gofmt
+			got := strings.Join(strings.Fields(string(b)), " ")
+			for _, want := range tc.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("expected %q in the rendered resource:\n%s", want, b)
+				}
+			}
+			for _, wantNot := range tc.wantNot {
+				if strings.Contains(got, wantNot) {
+					t.Errorf("expected no %q in the rendered resource:\n%s", wantNot, b)
+				}
+			}
+		})
+	}
+}
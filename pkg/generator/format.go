@@ -0,0 +1,160 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	errFormatSource = "cannot format generated source"
+)
+
+// formatSource removes the unused imports from the generated go source and formats it
+// like gofmt. When the source does not parse, the error shows the offending source lines.
+func formatSource(src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, errors.New(sourceError(src, err))
+	}
+	pruneImports(f)
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, f); err != nil {
+		return nil, err
+	}
+	// format the source once more, to remove the gaps the pruned imports leave behind
+	return format.Source(buf.Bytes())
+}
+
+// sourceError adds the source line to the errors of the parser
+func sourceError(src []byte, err error) string {
+	el, ok := err.(scanner.ErrorList)
+	if !ok {
+		return err.Error()
+	}
+	lines := strings.Split(string(src), "\n")
+	msgs := make([]string, 0, len(el))
+	for _, e := range el {
+		msg := e.Error()
+		if e.Pos.Line > 0 && e.Pos.Line <= len(lines) {
+			msg += ": " + strings.TrimSpace(lines[e.Pos.Line-1])
+		}
+		msgs = append(msgs, msg)
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// pruneImports removes the imports of packages that are not referenced in the file
+func pruneImports(f *ast.File) {
+	used := map[string]bool{}
+	ast.Inspect(f, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			// package identifiers are not resolved by the parser
+			if id, ok := sel.X.(*ast.Ident); ok && id.Obj == nil {
+				used[id.Name] = true
+			}
+		}
+		return true
+	})
+
+	decls := make([]ast.Decl, 0, len(f.Decls))
+	imports := make([]*ast.ImportSpec, 0, len(f.Imports))
+	for _, d := range f.Decls {
+		gd, ok := d.(*ast.GenDecl)
+		if !ok || gd.Tok != token.IMPORT {
+			decls = append(decls, d)
+			continue
+		}
+		specs := make([]ast.Spec, 0, len(gd.Specs))
+		for _, s := range gd.Specs {
+			is := s.(*ast.ImportSpec)
+			if isImportUsed(is, used) {
+				specs = append(specs, is)
+				imports = append(imports, is)
+			}
+		}
+		if len(specs) == 0 {
+			continue
+		}
+		gd.Specs = specs
+		decls = append(decls, gd)
+	}
+	f.Decls = decls
+	f.Imports = imports
+}
+
+func isImportUsed(is *ast.ImportSpec, used map[string]bool) bool {
+	if is.Name != nil {
+		switch is.Name.Name {
+		case "_", ".":
+			return true
+		default:
+			return used[is.Name.Name]
+		}
+	}
+	path, err := strconv.Unquote(is.Path.Value)
+	if err != nil {
+		return true
+	}
+	for _, name := range importPathToAssumedNames(path) {
+		if used[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// importPathToAssumedNames returns the package names that are assumed for an import path,
+// based on the last element of the path. When the last element is a version like v1 it can
+// either be the package name or a major version suffix of the module, in which case the
+// package is named after the previous element.
+func importPathToAssumedNames(path string) []string {
+	elems := strings.Split(path, "/")
+	names := []string{elems[len(elems)-1]}
+	if len(elems) > 1 && isMajorVersion(names[0]) {
+		names = append(names, elems[len(elems)-2])
+	}
+	for i, name := range names {
+		name = strings.TrimPrefix(name, "go-")
+		if j := strings.IndexFunc(name, func(r rune) bool {
+			return !(r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+		}); j >= 0 {
+			name = name[:j]
+		}
+		names[i] = name
+	}
+	return names
+}
+
+func isMajorVersion(s string) bool {
+	if len(s) < 2 || s[0] != 'v' {
+		return false
+	}
+	_, err := strconv.Atoi(s[1:])
+	return err == nil
+}
@@ -18,11 +18,10 @@ package generator
 
 import (
 	"fmt"
-	"io/ioutil"
-	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 	"text/template"
 
 	"github.com/openconfig/gnmi/proto/gnmi"
@@ -33,18 +32,23 @@ import (
 	"github.com/yndd/ndd-yang/pkg/container"
 	"github.com/yndd/ndd-yang/pkg/resource"
 	"github.com/yndd/ndd-yang/pkg/yparser"
+	"github.com/yndd/ndd-ygen/pkg/fsys"
 	"github.com/yndd/ndd-ygen/pkg/templ"
 	"github.com/yndd/ndd-ygen/pkg/utils"
-	"gopkg.in/yaml.v2"
 )
 
 const (
 	errResourceInputFileDoesNotExist = "resource input file does not exist, specify with -r"
 	errResourceInputFileRead         = "cannot read resource input file"
-	errResourceInputFileUnMarshal    = "cannot unmarshal resource input fiel"
+	errResourceInputFileUnMarshal    = "cannot unmarshal resource input file"
 	errCannotInitializeResources     = "cannot initialize resource from resource inout file"
 	errResourceNotFound              = "cannot find resource"
 	errParseTemplate                 = "cannot parse template"
+	errInvalidArchiveGlob            = "invalid glob pattern"
+	errNoArchiveModules              = "no yang modules match in archive"
+
+	// defaultArchiveGlob matches the yang modules in an archive that are processed
+	defaultArchiveGlob = "**/*.yang"
 )
 
 type Generator struct {
@@ -55,6 +59,8 @@ type Generator struct {
 	staticLeafRef map[string]string
 	resources     []*resource.Resource // holds the resources that are being generated
 	rootResource  *resource.Resource
+	resourceTrie  *resourceTrie           // index on the resource and exclude paths
+	sourceTrie    *resourceTrie           // index on the resource paths without the module
 	entries       []*yang.Entry           // Yang entries parsed from the yang files
 	modules       map[string]*yang.Module // Yang modules parsed from the yang files
 	template      *template.Template
@@ -62,6 +68,21 @@ type Generator struct {
 	healthStatus  bool
 	localRender   bool
 	debug         bool
+	// records the yang choices and cases of the container entries
+	entryChoiceCases map[*container.Entry][]*ChoiceCase
+	dryRun           bool
+	inputFS          fsys.FS      // the filesystem the input files are read from
+	outputFS         fsys.WriteFS // the filesystem the output files are written to
+	warnings         []error      // the errors of goyang that are ignored when not in strict mode
+	// the files that are rendered, keyed by the path of the file
+	renderedFiles      map[string]*renderedFile
+	renderKinds        map[string]bool // the render kinds that are rendered
+	renderedFilesMutex sync.Mutex
+
+	// records the yang types of the leaf container entries
+	entryYangTypes map[*container.Entry]*yang.YangType
+	// records the leaf of the leaf-list container entries
+	entryLeafLists map[*container.Entry]*container.Entry
 }
 
 // Option can be used to manipulate Options.
@@ -91,6 +112,46 @@ func WithYangModuleDirs(d []string) Option {
 	}
 }
 
+func WithDeviationModules(d []string) Option {
+	return func(g *Generator) {
+		g.config.deviationModules = d
+	}
+}
+
+// WithFeatures enables the features, specified as module:feature, of the yang modules.
+// When set, the nodes guarded by an if-feature statement that evaluates to false are
+// pruned from the schema. When not set all features are considered to be enabled.
+func WithFeatures(f []string) Option {
+	return func(g *Generator) {
+		g.config.features = append([]string{}, f...)
+	}
+}
+
+// WithYangLibrary selects the yang modules, revisions, features and deviations listed in
+// the RFC 8525 yang library document in JSON or XML encoding. The modules are searched in
+// the yang module and import dirs.
+func WithYangLibrary(s string) Option {
+	return func(g *Generator) {
+		g.config.yangLibrary = s
+	}
+}
+
+// WithModuleRevisions pins the revisions of the yang modules, specified as module@revision.
+// When multiple revisions of a module are read, only the pinned revision is processed.
+func WithModuleRevisions(r []string) Option {
+	return func(g *Generator) {
+		g.config.moduleRevisions = r
+	}
+}
+
+// WithStrict returns the errors of goyang when processing the yang modules,
+// otherwise the errors are logged and collected as warnings
+func WithStrict(b bool) Option {
+	return func(g *Generator) {
+		g.config.strict = b
+	}
+}
+
 func WithResourceMapInputFile(s string) Option {
 	return func(g *Generator) {
 		g.config.resourceMapInputFile = s
@@ -133,12 +194,69 @@ func WithPrefix(s string) Option {
 	}
 }
 
+func WithCrdOutputDir(s string) Option {
+	return func(g *Generator) {
+		g.config.crdOutputDir = s
+	}
+}
+
+func WithExampleOutputDir(s string) Option {
+	return func(g *Generator) {
+		g.config.exampleOutputDir = s
+	}
+}
+
+// WithGoModule sets the go module of the output directory, the controllers import
+// the api package and each other relative to the go module
+func WithGoModule(s string) Option {
+	return func(g *Generator) {
+		g.config.goModule = s
+	}
+}
+
+func WithTemplateDir(s string) Option {
+	return func(g *Generator) {
+		g.config.templateDir = s
+	}
+}
+
+// WithJobs sets the number of files that are read and rendered concurrently,
+// the number of CPUs is used when not set
+func WithJobs(n int) Option {
+	return func(g *Generator) {
+		g.config.jobs = n
+	}
+}
+
 func WithHealthStatus(b bool) Option {
 	return func(g *Generator) {
 		g.healthStatus = b
 	}
 }
 
+// WithInputFS sets the filesystem the resource map, yang modules and templates are
+// read from, the filesystem of the operating system is used when not set
+func WithInputFS(f fsys.FS) Option {
+	return func(g *Generator) {
+		g.inputFS = f
+	}
+}
+
+// WithOutputFS sets the filesystem the rendered files are written to, the
+// filesystem of the operating system is used when not set
+func WithOutputFS(f fsys.WriteFS) Option {
+	return func(g *Generator) {
+		g.outputFS = f
+	}
+}
+
+// WithDryRun renders the files in memory without writing them to the output directories
+func WithDryRun(b bool) Option {
+	return func(g *Generator) {
+		g.dryRun = b
+	}
+}
+
 func WithLocalRender(b bool) Option {
 	return func(g *Generator) {
 		g.localRender = b
@@ -148,8 +266,15 @@ func WithLocalRender(b bool) Option {
 // NewYangGoCodeGenerator function defines a new generator
 func NewGenerator(opts ...Option) (*Generator, error) {
 	g := &Generator{
-		config:    &Config{},
-		resources: make([]*resource.Resource, 0),
+		config:           &Config{},
+		resources:        make([]*resource.Resource, 0),
+		entryChoiceCases: make(map[*container.Entry][]*ChoiceCase),
+		entryYangTypes:   make(map[*container.Entry]*yang.YangType),
+		entryLeafLists:   make(map[*container.Entry]*container.Entry),
+		renderedFiles:    make(map[string]*renderedFile),
+		renderKinds:      make(map[string]bool),
+		inputFS:          fsys.NewOSFS(),
+		outputFS:         fsys.NewOSFS(),
 	}
 
 	for _, o := range opts {
@@ -159,24 +284,23 @@ func NewGenerator(opts ...Option) (*Generator, error) {
 	// process templates to render the resources
 	if g.GetLocalRender() {
 		if err := g.initTemplates(); err != nil {
-			return nil, errors.New(errParseTemplate)
+			return nil, errors.Wrap(err, errParseTemplate)
 		}
 	}
 
 	// Process resource
 	// Check if the resource input file exists
-	if !utils.FileExists(g.GetConfig().GetResourceMapInputFile()) {
+	if !utils.FileExists(g.GetInputFS(), g.GetConfig().GetResourceMapInputFile()) {
 		return nil, errors.New(errResourceInputFileDoesNotExist)
 	}
 
-	c := &ResourceYamlInput{}
-	yamlFile, err := ioutil.ReadFile(g.GetConfig().GetResourceMapInputFile())
+	yamlFile, err := g.GetInputFS().ReadFile(g.GetConfig().GetResourceMapInputFile())
 	if err != nil {
 		return nil, errors.Wrap(err, errResourceInputFileRead)
 	}
-	err = yaml.Unmarshal(yamlFile, c)
+	c, err := DecodeResourceYamlInput(yamlFile)
 	if err != nil {
-		return nil, errors.Wrap(err, errResourceInputFileUnMarshal)
+		return nil, errors.Wrapf(err, "%s %s", errResourceInputFileUnMarshal, g.GetConfig().GetResourceMapInputFile())
 	}
 
 	g.schema = c.Schema
@@ -184,7 +308,7 @@ func NewGenerator(opts ...Option) (*Generator, error) {
 
 	for localPath, remotePath := range g.staticLeafRef {
 		rPath := yparser.Xpath2GnmiPath(remotePath, 0)
-		fmt.Printf("localLeafRef: %s \n   RemoteLeafRef: %s \n   RemoteGnmiPath %v\n", localPath, remotePath, rPath)
+		g.log.Debug("static leafref", "localLeafRef", localPath, "remoteLeafRef", remotePath, "remoteGnmiPath", yparser.GnmiPath2XPath(rPath, false))
 	}
 	// initialize the resources from the YAML input file, we start at the root level using "/" path
 	g.rootResource = resource.NewResource(nil)
@@ -192,6 +316,8 @@ func NewGenerator(opts ...Option) (*Generator, error) {
 	if err := g.InitializeResources(c.Path, "/", g.rootResource); err != nil {
 		return nil, errors.Wrap(err, errCannotInitializeResources)
 	}
+	g.resourceTrie = newResourceTrie(g.GetResources()[1:])
+	g.sourceTrie = newResourceSourceTrie(g.GetResources()[1:])
 
 	// show the result of the processed resources
 	//g.ShowResources()
@@ -221,6 +347,18 @@ func (g *Generator) GetLocalRender() bool {
 	return g.localRender
 }
 
+func (g *Generator) GetInputFS() fsys.FS {
+	return g.inputFS
+}
+
+func (g *Generator) GetOutputFS() fsys.WriteFS {
+	return g.outputFS
+}
+
+func (g *Generator) GetDryRun() bool {
+	return g.dryRun
+}
+
 func (g *Generator) GetDebug() bool {
 	return g.debug
 }
@@ -239,7 +377,7 @@ func (g *Generator) getTemplate() *template.Template {
 
 func (g *Generator) initTemplates() error {
 	var err error
-	g.template, err = templ.ParseTemplates("./templates/")
+	g.template, err = templ.ParseTemplates(g.GetInputFS(), g.GetConfig().GetTemplateDir())
 	if err != nil {
 		return err
 	}
@@ -269,66 +407,261 @@ func (g *Generator) initializeGoYang() ([]*yang.Entry, map[string]*yang.Module,
 	// Append the includePaths to the Goyang path variable, this ensures
 	// that where a YANG module uses an 'include' statement to reference
 	// another module, then Goyang can find this module to process.
+	// goyang can only find the modules on the filesystem of the operating system
+	_, osFS := g.GetInputFS().(*fsys.OSFS)
+	importPaths := make([]string, 0)
 	for _, path := range g.GetConfig().GetYangImportDirs() {
-		moduleSet.AddPath(path)
+		if _, _, ok := fsys.SplitArchivePath(path); osFS && !ok {
+			moduleSet.AddPath(path)
+			continue
+		}
+		importPaths = append(importPaths, path)
+	}
+
+	// Read the yang directory, or the modules listed in the yang library
+	var lib *yangLibrary
+	var modules, imports []*yangFiles
+	var err error
+	if g.GetConfig().GetYangLibrary() != "" {
+		lib, err = g.readYangLibrary(g.GetConfig().GetYangLibrary())
+		if err != nil {
+			return nil, nil, err
+		}
+		modules, imports, err = g.getYangLibraryFiles(lib)
+	} else {
+		modules, imports, err = g.getYangFiles(g.GetConfig().GetYangModuleDirs())
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, yf := range modules {
+		if err := g.parseYangFiles(moduleSet, yf, false); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Read the deviation modules, the deviations are applied by goyang when
+	// processing the modules. We keep track of the revisions of the top level
+	// modules since the deviation and import modules dont hold schema nodes we
+	// want to process
+	topModules := map[string]bool{}
+	for _, m := range moduleSet.Modules {
+		topModules[m.FullName()] = true
+	}
+	deviations, _, err := g.getYangFiles(g.GetConfig().GetDeviationModules())
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, yf := range deviations {
+		if err := g.parseYangFiles(moduleSet, yf, false); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// the imports which cannot be resolved by goyang, are read upfront. The yang
+	// library lists the import modules, such that other revisions are not read.
+	if lib == nil {
+		importFiles, _, err := g.getYangFiles(importPaths)
+		if err != nil {
+			return nil, nil, err
+		}
+		imports = append(imports, importFiles...)
+	}
+	for _, yf := range imports {
+		if err := g.parseYangFiles(moduleSet, yf, true); err != nil {
+			return nil, nil, err
+		}
 	}
 
-	// Read the yang directory
-	for _, d := range g.GetConfig().GetYangModuleDirs() {
-		fi, err := os.Stat(d)
+	// Ensure a single revision of the top level modules is processed
+	names, err := g.resolveModuleRevisions(moduleSet, topModules)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Process the yang modules
+	if err := g.processModules(moduleSet); err != nil {
+		return nil, nil, err
+	}
+	// The top level modules we read in are the only modules we want to process,
+	// the module is registered by its name for the selected revision
+	mods := map[string]*yang.Module{}
+	entries := make([]*yang.Entry, len(names))
+	for x, n := range names {
+		mods[n] = moduleSet.Modules[n]
+		entries[x] = yang.ToEntry(mods[n])
+	}
+
+	// prune the nodes of which the if-feature statements are not satisfied,
+	// the features of the yang library are enabled in addition to the configured features
+	features := g.GetConfig().GetFeatures()
+	if lib != nil {
+		features = append(append([]string{}, features...), lib.getFeatures()...)
+	}
+	if features != nil {
+		ef, err := parseFeatures(features)
 		if err != nil {
 			return nil, nil, err
 		}
+		for _, e := range entries {
+			if err := ef.pruneFeatures(e); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	return entries, mods, nil
+}
+
+// yangFiles are yang files on a filesystem
+type yangFiles struct {
+	fs fsys.FS
+	// the archive of the files, empty when the files are not in an archive
+	archive string
+	files   []string
+}
+
+// getYangFiles returns the yang files of the supplied directories, files or archives.
+// The paths of archives have the format <archive>[:<glob>], the files in the archive
+// that match the glob, by default all .yang files, are returned as modules and the
+// other .yang files in the archive are returned as imports.
+func (g *Generator) getYangFiles(paths []string) ([]*yangFiles, []*yangFiles, error) {
+	modules := make([]*yangFiles, 0)
+	imports := make([]*yangFiles, 0)
+	for _, d := range paths {
+		if archive, glob, ok := fsys.SplitArchivePath(d); ok {
+			b, err := g.GetInputFS().ReadFile(archive)
+			if err != nil {
+				return nil, nil, err
+			}
+			afs, err := fsys.NewArchiveFS(archive, b)
+			if err != nil {
+				return nil, nil, err
+			}
+			all, err := afs.Glob(defaultArchiveGlob)
+			if err != nil {
+				return nil, nil, err
+			}
+			if glob == "" {
+				glob = defaultArchiveGlob
+			}
+			matched, err := afs.Glob(glob)
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "%s %s", errInvalidArchiveGlob, glob)
+			}
+			if len(matched) == 0 {
+				return nil, nil, errors.Errorf("%s %s", errNoArchiveModules, d)
+			}
+			m := make(map[string]bool)
+			for _, name := range matched {
+				m[name] = true
+			}
+			other := make([]string, 0)
+			for _, name := range all {
+				if !m[name] {
+					other = append(other, name)
+				}
+			}
+			modules = append(modules, &yangFiles{fs: afs, archive: archive, files: matched})
+			imports = append(imports, &yangFiles{fs: afs, archive: archive, files: other})
+			continue
+		}
+
+		fi, err := g.GetInputFS().Stat(d)
+		if err != nil {
+			return nil, nil, err
+		}
+		yf := &yangFiles{fs: g.GetInputFS()}
 		switch mode := fi.Mode(); {
 		case mode.IsDir():
 			// Handle directory files input
-			files, err := ioutil.ReadDir(d)
+			fis, err := g.GetInputFS().ReadDir(d)
 			if err != nil {
 				return nil, nil, err
 			}
-			for _, f := range files {
-				//g.log.Debug("Yang File Info", "FileName", d+"/"+f.Name())
-				if err := moduleSet.Read(d + "/" + f.Name()); err != nil {
-					return nil, nil, err
-				}
+			for _, f := range fis {
+				yf.files = append(yf.files, d+"/"+f.Name())
 			}
 		case mode.IsRegular():
 			// Handle file input
-			//g.log.Debug("Yang File Info", "FileName", fi.Name())
-			if err := moduleSet.Read(filepath.Dir(d) + fi.Name()); err != nil {
-				return nil, nil, err
-				//continue
-			}
+			yf.files = append(yf.files, d)
 		}
+		modules = append(modules, yf)
+	}
+	return modules, imports, nil
+}
+
+// parseYangFiles reads the yang files and adds the modules to the module set.
+// goyang does not allow to add modules to a module set concurrently, hence
+// the files are read concurrently and parsed in the order of the files.
+// When imports is set, only the .yang files are read and the modules that
+// are already read are skipped.
+func (g *Generator) parseYangFiles(moduleSet *yang.Modules, yf *yangFiles, imports bool) error {
+	_, osFS := yf.fs.(*fsys.OSFS)
+	files := make([]string, 0, len(yf.files))
+	for _, file := range yf.files {
+		if imports && !strings.HasSuffix(file, ".yang") {
+			continue
+		}
+		files = append(files, file)
 	}
 
-	// Process the yang modules
-	errs := moduleSet.Process()
-	if len(errs) > 0 {
-		for err := range errs {
-			g.log.Debug("Error", "error", err)
-		}
+	data := make([][]byte, len(files))
+	jobs := make([]func() error, 0, len(files))
+	for i, file := range files {
+		i, file := i, file
+		jobs = append(jobs, func() error {
+			var err error
+			data[i], err = yf.fs.ReadFile(file)
+			return err
+		})
 	}
-	// Keep track of the top level modules we read in.
-	// Those are the only modules we want to process.
-	mods := map[string]*yang.Module{}
-	var names []string
-	for _, m := range moduleSet.Modules {
-		if mods[m.Name] == nil {
-			mods[m.Name] = m
-			names = append(names, m.Name)
+	if err := runJobs(g.GetConfig().GetJobs(), jobs); err != nil {
+		return err
+	}
+
+	for i, file := range files {
+		//g.log.Debug("Yang File Info", "FileName", file)
+		// the files in an archive are named after the archive for the errors of goyang
+		name := file
+		if yf.archive != "" {
+			name = yf.archive + ":" + filepath.ToSlash(file)
+		}
+		if imports {
+			loaded, err := isModuleLoaded(moduleSet, string(data[i]), name)
+			if err != nil {
+				return err
+			}
+			if loaded {
+				continue
+			}
+		}
+		if osFS {
+			moduleSet.AddPath(filepath.Dir(file))
+		}
+		if err := moduleSet.Parse(string(data[i]), name); err != nil {
+			return err
 		}
 	}
-	sort.Strings(names)
-	entries := make([]*yang.Entry, len(names))
-	for x, n := range names {
-		entries[x] = yang.ToEntry(mods[n])
+	return nil
+}
+
+// isModuleLoaded returns true if the (sub)modules of the yang file are already in the module set
+func isModuleLoaded(moduleSet *yang.Modules, data, file string) (bool, error) {
+	hs, err := getModuleHeaders(data, file)
+	if err != nil {
+		return false, err
 	}
-	return entries, mods, nil
+	for _, h := range hs {
+		if moduleSet.Modules[h.fullName()] == nil && moduleSet.SubModules[h.fullName()] == nil {
+			return false, nil
+		}
+	}
+	return true, nil
 }
 
 func (g *Generator) Run() error {
 	// Augment the data
+	var errs Errors
 	for _, e := range g.getEntries() {
 		//g.log.Debug("Yang global Entry: ", "Nbr", i, "Name", e.Name)
 
@@ -337,9 +670,12 @@ func (g *Generator) Run() error {
 			Elem: make([]*gnmi.PathElem, 0),
 		}
 		if err := g.ResourceGenerator("", path, e, false, "", ""); err != nil {
-			return err
+			errs = appendError(errs, err)
 		}
 	}
+	if err := errs.ErrorOrNil(); err != nil {
+		return err
+	}
 	g.updateContainerLeafRefTypes()
 	// updates the container has state
 	g.updateContainerStateChildStatus()
@@ -426,7 +762,14 @@ func (g *Generator) updateContainerStateChildStatus() {
 // A resource contains the relative information of the resource.
 // we generate both a resource list as well as a linked list with parent and child
 func (g *Generator) InitializeResources(pd map[string]PathDetails, pp string, parent *resource.Resource) error {
-	for path, pathdetails := range pd {
+	// the paths are sorted to initialize the resources in a stable order
+	paths := make([]string, 0, len(pd))
+	for path := range pd {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		pathdetails := pd[path]
 		//g.log.Debug("Path information", "Path", path, "parent path", pp)
 		opts := []resource.Option{}
 		if pp == "/" {
@@ -0,0 +1,224 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yndd/ndd-runtime/pkg/logging"
+	"github.com/yndd/ndd-ygen/pkg/fsys"
+)
+
+const testInterfacesYang = `module test-interfaces {
+  namespace "urn:test:interfaces";
+  prefix tif;
+  import test-types { prefix tt; }
+
+  container interfaces {
+    list interface {
+      key "name";
+      leaf name {
+        type string;
+      }
+      leaf mtu {
+        type tt:mtu;
+      }
+    }
+  }
+}
+`
+
+const testTypesYang = `module test-types {
+  namespace "urn:test:types";
+  prefix tt;
+
+  typedef mtu {
+    type uint16 {
+      range "1500..9500";
+    }
+  }
+}
+`
+
+func TestGeneratorMemFS(t *testing.T) {
+	inputFS := fsys.NewMemFS()
+	for name, data := range map[string]string{
+		"yang/test-interfaces.yang": testInterfacesYang,
+		"import/test-types.yang":    testTypesYang,
+		"map.yaml":                  "path:\n  /test-interfaces/interfaces/interface:\n",
+	} {
+		if err := inputFS.WriteFile(name, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	outputFS := fsys.NewMemFS()
+
+	g, err := NewGenerator(
+		WithInputFS(inputFS),
+		WithOutputFS(outputFS),
+		WithLogging(logging.NewNopLogger()),
+		WithYangImportDirs([]string{"import"}),
+		WithYangModuleDirs([]string{"yang"}),
+		WithResourceMapInputFile("map.yaml"),
+		WithOutputDir("out"),
+		WithVersion("v1alpha1"),
+		WithAPIGroup("test.ndd.yndd.io"),
+		WithPrefix("test"),
+		WithLocalRender(true),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Render(); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.WriteManifest(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"out/.ndd-ygen-manifest.json",
+		"out/apis/v1alpha1/groupversion_info.go",
+		"out/apis/v1alpha1/test_interfaces_interface_types.go",
+		"out/apis/v1alpha1/zz_generated.managed.go",
+	}
+	if got := outputFS.GetFileNames(); !reflect.DeepEqual(got, want) {
+		t.Errorf("rendered files: got %v, want %v", got, want)
+	}
+	b, err := outputFS.ReadFile("out/apis/v1alpha1/test_interfaces_interface_types.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), "Mtu  *uint16") {
+		t.Errorf("expected the imported mtu type to be resolved:\n%s", b)
+	}
+}
+
+// writeTestArchive writes the bundle as an archive in the format of the extension of the name
+func writeTestArchive(t *testing.T, name string, bundle *fsys.MemFS) []byte {
+	var buf bytes.Buffer
+	switch {
+	case strings.HasSuffix(name, ".zip"):
+		zw := zip.NewWriter(&buf)
+		for _, f := range bundle.GetFileNames() {
+			b, err := bundle.ReadFile(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			w, err := zw.Create(filepath.ToSlash(f))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := w.Write(b); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := zw.Close(); err != nil {
+			t.Fatal(err)
+		}
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		zw := gzip.NewWriter(&buf)
+		if err := bundle.WriteTar(zw); err != nil {
+			t.Fatal(err)
+		}
+		if err := zw.Close(); err != nil {
+			t.Fatal(err)
+		}
+	default:
+		if err := bundle.WriteTar(&buf); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return buf.Bytes()
+}
+
+func TestGeneratorArchive(t *testing.T) {
+	bundle := fsys.NewMemFS()
+	for name, data := range map[string]string{
+		"bundle/test-interfaces.yang":   testInterfacesYang,
+		"bundle/common/test-types.yang": testTypesYang,
+	} {
+		if err := bundle.WriteFile(name, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, archive := range []string{"bundle.tar", "bundle.tar.gz", "bundle.tgz", "bundle.zip"} {
+		t.Run(archive, func(t *testing.T) {
+			inputFS := fsys.NewMemFS()
+			if err := inputFS.WriteFile(archive, writeTestArchive(t, archive, bundle), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := inputFS.WriteFile("map.yaml", []byte("path:\n  /test-interfaces/interfaces/interface:\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			outputFS := fsys.NewMemFS()
+
+			// the modules of the archive that do not match the glob are only used as imports
+			g, err := NewGenerator(
+				WithInputFS(inputFS),
+				WithOutputFS(outputFS),
+				WithLogging(logging.NewNopLogger()),
+				WithYangModuleDirs([]string{archive + ":bundle/*.yang"}),
+				WithResourceMapInputFile("map.yaml"),
+				WithOutputDir("out"),
+				WithVersion("v1alpha1"),
+				WithAPIGroup("test.ndd.yndd.io"),
+				WithPrefix("test"),
+				WithLocalRender(true),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := g.Run(); err != nil {
+				t.Fatal(err)
+			}
+			if err := g.Render(); err != nil {
+				t.Fatal(err)
+			}
+
+			b, err := outputFS.ReadFile("out/apis/v1alpha1/test_interfaces_interface_types.go")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(b), "Mtu  *uint16") {
+				t.Errorf("expected the imported mtu type to be resolved:\n%s", b)
+			}
+
+			for _, glob := range []string{"other/*.yang", "bundle/**/*.txt"} {
+				if _, err := NewGenerator(
+					WithInputFS(inputFS),
+					WithOutputFS(outputFS),
+					WithLogging(logging.NewNopLogger()),
+					WithYangModuleDirs([]string{archive + ":" + glob}),
+					WithResourceMapInputFile("map.yaml"),
+				); err == nil || !strings.Contains(err.Error(), errNoArchiveModules) {
+					t.Errorf("%s: got %v, want an error when no module in the archive matches the glob", glob, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,270 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/yndd/ndd-runtime/pkg/logging"
+	"github.com/yndd/ndd-ygen/pkg/fsys"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata/<case>/golden")
+
+const (
+	testdataDir   = "testdata"
+	testOutputDir = "out"
+	// testGoModule is the go module of the rendered go packages
+	testGoModule = "github.com/yndd/sample"
+)
+
+// goldenCase renders the yang modules in testdata/yang with the resource map in
+// testdata/<name>/map.yaml and compares the output with testdata/<name>/golden
+type goldenCase struct {
+	name   string
+	opts   []Option
+	render func(g *Generator) error
+	// the go packages of the golden files that are built and vetted
+	build []string
+}
+
+func TestGolden(t *testing.T) {
+	cases := []goldenCase{
+		{
+			name: "resources",
+			opts: []Option{
+				WithCrdOutputDir(filepath.Join(testOutputDir, "crds")),
+				WithGoModule(testGoModule),
+				WithExampleOutputDir(filepath.Join(testOutputDir, "examples")),
+			},
+			render: func(g *Generator) error {
+				if err := g.Render(); err != nil {
+					return err
+				}
+				if err := g.RenderDeepCopy(); err != nil {
+					return err
+				}
+				if err := g.RenderRFC7951(); err != nil {
+					return err
+				}
+				if err := g.RenderControllers(); err != nil {
+					return err
+				}
+				if err := g.RenderExamples(); err != nil {
+					return err
+				}
+				return g.RenderCRDs()
+			},
+			build: []string{"./..."},
+		},
+		{
+			name: "schema",
+			opts: []Option{
+				WithResourceMapAll(true),
+			},
+			render: func(g *Generator) error {
+				return g.RenderSchema()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			outputFS := fsys.NewMemFS()
+			opts := append([]Option{
+				WithOutputFS(outputFS),
+				WithLogging(logging.NewNopLogger()),
+				WithYangImportDirs([]string{filepath.Join(testdataDir, "yang")}),
+				WithYangModuleDirs([]string{filepath.Join(testdataDir, "yang")}),
+				WithResourceMapInputFile(filepath.Join(testdataDir, tc.name, "map.yaml")),
+				WithOutputDir(testOutputDir),
+				WithVersion("v1alpha1"),
+				WithAPIGroup("sample.ndd.yndd.io"),
+				WithPrefix("sample"),
+				WithLocalRender(true),
+			}, tc.opts...)
+
+			g, err := NewGenerator(opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := g.Run(); err != nil {
+				t.Fatal(err)
+			}
+			if err := tc.render(g); err != nil {
+				t.Fatal(err)
+			}
+
+			goldenDir := filepath.Join(testdataDir, tc.name, "golden")
+			got := map[string][]byte{}
+			for _, name := range outputFS.GetFileNames() {
+				b, err := outputFS.ReadFile(name)
+				if err != nil {
+					t.Fatal(err)
+				}
+				rel, err := filepath.Rel(testOutputDir, name)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got[rel] = b
+			}
+
+			if *update {
+				updateGolden(t, goldenDir, got)
+				return
+			}
+			compareGolden(t, goldenDir, got)
+			if len(tc.build) > 0 {
+				buildGolden(t, goldenDir, tc.build)
+			}
+		})
+	}
+}
+
+// buildGolden builds and vets the go packages of the golden files
+func buildGolden(t *testing.T, goldenDir string, pkgs []string) {
+	files := map[string][]byte{}
+	err := filepath.Walk(goldenDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || filepath.Ext(path) != ".go" {
+			return err
+		}
+		rel, err := filepath.Rel(goldenDir, path)
+		if err != nil {
+			return err
+		}
+		files[rel], err = os.ReadFile(path)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	runGoModule(t, files, append([]string{"build"}, pkgs...), append([]string{"vet"}, pkgs...))
+}
+
+// runGoModule writes the files to the go module testGoModule in a temporary directory
+// and runs the go commands in the module, the module requires the dependencies of the
+// generator such that the commands run without network access
+func runGoModule(t *testing.T, files map[string][]byte, cmds ...[]string) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("skipping the go commands on the rendered files in short mode")
+	}
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("skipping the go commands on the rendered files, the go tool is not found")
+	}
+
+	goMod, err := os.ReadFile(filepath.Join("..", "..", "go.mod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	goSum, err := os.ReadFile(filepath.Join("..", "..", "go.sum"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.SplitN(string(goMod), "\n", 2)
+	goMod = []byte("module " + testGoModule + "\n" + lines[1])
+
+	dir := t.TempDir()
+	files["go.mod"], files["go.sum"] = goMod, goSum
+	for rel, b := range files {
+		name := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, b, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, args := range cmds {
+		cmd := exec.Command(goTool, args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Errorf("go %s: %v\n%s", strings.Join(args, " "), err, out)
+		}
+	}
+}
+
+// updateGolden replaces the golden files with the rendered files
+func updateGolden(t *testing.T, goldenDir string, got map[string][]byte) {
+	if err := os.RemoveAll(goldenDir); err != nil {
+		t.Fatal(err)
+	}
+	for rel, b := range got {
+		name := filepath.Join(goldenDir, rel)
+		if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, b, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// compareGolden compares the rendered files with the golden files
+func compareGolden(t *testing.T, goldenDir string, got map[string][]byte) {
+	want := map[string][]byte{}
+	err := filepath.Walk(goldenDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(goldenDir, path)
+		if err != nil {
+			return err
+		}
+		want[rel] = b
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for rel, b := range got {
+		w, ok := want[rel]
+		if !ok {
+			t.Errorf("%s: rendered file has no golden file, run go test -update to add it", rel)
+			continue
+		}
+		if string(w) != string(b) {
+			diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+				A:        difflib.SplitLines(string(w)),
+				B:        difflib.SplitLines(string(b)),
+				FromFile: "golden/" + rel,
+				ToFile:   "rendered/" + rel,
+				Context:  3,
+			})
+			t.Errorf("%s: rendered file differs from the golden file, run go test -update if this is expected:\n%s", rel, strings.TrimSpace(diff))
+		}
+	}
+	for rel := range want {
+		if _, ok := got[rel]; !ok {
+			t.Errorf("%s: golden file is not rendered, run go test -update to remove it", rel)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/pkg/errors"
+	"github.com/yndd/ndd-yang/pkg/resource"
+	"github.com/yndd/ndd-yang/pkg/yparser"
+)
+
+const (
+	// GraphFormatDOT renders the graph in the graphviz DOT language
+	GraphFormatDOT = "dot"
+	// GraphFormatMermaid renders the graph as a mermaid flowchart
+	GraphFormatMermaid = "mermaid"
+	// GraphFormatJSON renders the graph as JSON
+	GraphFormatJSON = "json"
+
+	// EdgeTypeParent is the dependency of a hierarchical resource on its parent
+	EdgeTypeParent = "parent"
+	// EdgeTypeLeafRef is the dependency of a resource on the target of an external leafref
+	EdgeTypeLeafRef = "leafref"
+	// EdgeTypeStaticLeafRef is the dependency of a resource on the target of a static leafref
+	EdgeTypeStaticLeafRef = "static-leafref"
+
+	// NodeKindUnmanaged is the kind of a leafref target that is not covered by any resource
+	NodeKindUnmanaged = "unmanaged"
+
+	errUnknownGraphFormat = "unknown graph format"
+)
+
+// Graph holds the resources and the dependencies between them,
+// an edge points from a resource to the resource it depends on
+type Graph struct {
+	Nodes []*GraphNode `json:"nodes"`
+	Edges []*GraphEdge `json:"edges"`
+}
+
+type GraphNode struct {
+	ID   string `json:"id"`
+	Kind string `json:"kind"`
+	Path string `json:"path"`
+}
+
+type GraphEdge struct {
+	From       string `json:"from"`
+	To         string `json:"to"`
+	Type       string `json:"type"`
+	LocalPath  string `json:"localPath,omitempty"`
+	RemotePath string `json:"remotePath,omitempty"`
+}
+
+// GetGraph returns the resource dependency graph; it holds the resource hierarchy and
+// the external and static leafrefs of the resources. It is available after Run.
+func (g *Generator) GetGraph() *Graph {
+	gr := &Graph{
+		Nodes: make([]*GraphNode, 0),
+		Edges: make([]*GraphEdge, 0),
+	}
+	nodes := map[string]*GraphNode{}
+	addNode := func(n *GraphNode) {
+		if _, ok := nodes[n.ID]; !ok {
+			nodes[n.ID] = n
+			gr.Nodes = append(gr.Nodes, n)
+		}
+	}
+
+	resources := g.GetResources()[1:]
+	for _, r := range resources {
+		addNode(&GraphNode{
+			ID:   r.GetAbsoluteName(),
+			Kind: r.GetResourceNameWithPrefix(g.GetConfig().GetPrefix()),
+			Path: yparser.GnmiPath2XPath(r.GetAbsolutePath(), false),
+		})
+	}
+	for _, r := range resources {
+		if p := r.GetParent(); p != nil && p.GetParent() != nil {
+			gr.Edges = append(gr.Edges, &GraphEdge{
+				From: r.GetAbsoluteName(),
+				To:   p.GetAbsoluteName(),
+				Type: EdgeTypeParent,
+			})
+		}
+		for _, lr := range r.GetExternalLeafRef() {
+			to := g.getGraphTarget(lr.RemotePath, addNode)
+			gr.Edges = append(gr.Edges, &GraphEdge{
+				From:       r.GetAbsoluteName(),
+				To:         to,
+				Type:       EdgeTypeLeafRef,
+				LocalPath:  yparser.GnmiPath2XPath(lr.LocalPath, true),
+				RemotePath: yparser.GnmiPath2XPath(lr.RemotePath, true),
+			})
+		}
+	}
+
+	// sort the static leafrefs to provide a stable output
+	localPaths := make([]string, 0, len(g.staticLeafRef))
+	for localPath := range g.staticLeafRef {
+		localPaths = append(localPaths, localPath)
+	}
+	sort.Strings(localPaths)
+	for _, localPath := range localPaths {
+		r, ok := g.findResourceFromPath(yparser.Xpath2GnmiPath(localPath, 0))
+		if !ok {
+			continue
+		}
+		remotePath := yparser.Xpath2GnmiPath(g.staticLeafRef[localPath], 0)
+		gr.Edges = append(gr.Edges, &GraphEdge{
+			From:       r.GetAbsoluteName(),
+			To:         g.getGraphTarget(remotePath, addNode),
+			Type:       EdgeTypeStaticLeafRef,
+			LocalPath:  localPath,
+			RemotePath: g.staticLeafRef[localPath],
+		})
+	}
+
+	sort.SliceStable(gr.Nodes, func(i, j int) bool { return gr.Nodes[i].ID < gr.Nodes[j].ID })
+	sort.SliceStable(gr.Edges, func(i, j int) bool {
+		if gr.Edges[i].From != gr.Edges[j].From {
+			return gr.Edges[i].From < gr.Edges[j].From
+		}
+		if gr.Edges[i].To != gr.Edges[j].To {
+			return gr.Edges[i].To < gr.Edges[j].To
+		}
+		return gr.Edges[i].LocalPath < gr.Edges[j].LocalPath
+	})
+	return gr
+}
+
+// getGraphTarget returns the id of the resource that covers the leafref path,
+// when no resource covers the path an unmanaged node is added
+func (g *Generator) getGraphTarget(p *gnmi.Path, addNode func(n *GraphNode)) string {
+	if r, ok := g.findResourceFromPath(p); ok {
+		return r.GetAbsoluteName()
+	}
+	path := yparser.GnmiPath2XPath(p, false)
+	addNode(&GraphNode{ID: path, Kind: NodeKindUnmanaged, Path: path})
+	return path
+}
+
+// findResourceFromPath finds the resource of a path that starts with the module or, as
+// is the case for leafref paths, without the module
+func (g *Generator) findResourceFromPath(p *gnmi.Path) (*resource.Resource, bool) {
+	if r, ok := g.FindBestMatch(p); ok {
+		return r, true
+	}
+	return g.findResourceFromSource(p)
+}
+
+// findResourceFromSource finds the resource with the longest match on the path, the path
+// does not contain the module as first element as is the case for leafref paths
+func (g *Generator) findResourceFromSource(p *gnmi.Path) (*resource.Resource, bool) {
+	r, _, ok := g.sourceTrie.findBestMatch(p)
+	return r, ok
+}
+
+// Write renders the graph in the format
+func (gr *Graph) Write(w io.Writer, format string) error {
+	switch format {
+	case GraphFormatDOT:
+		return gr.WriteDOT(w)
+	case GraphFormatMermaid:
+		return gr.WriteMermaid(w)
+	case GraphFormatJSON:
+		return gr.WriteJSON(w)
+	default:
+		return errors.Errorf("%s: %s", errUnknownGraphFormat, format)
+	}
+}
+
+// WriteDOT renders the graph in the graphviz DOT language, leafref edges
+// between the same resources are merged
+func (gr *Graph) WriteDOT(w io.Writer) error {
+	sb := &strings.Builder{}
+	sb.WriteString("digraph resources {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [shape=box];\n")
+	for _, n := range gr.Nodes {
+		style := ""
+		if n.Kind == NodeKindUnmanaged {
+			style = ", style=dashed"
+		}
+		fmt.Fprintf(sb, "  %q [label=%q%s];\n", n.ID, n.Kind+"\n"+n.Path, style)
+	}
+	for _, e := range gr.getUniqueEdges() {
+		style := ""
+		if e.Type == EdgeTypeParent {
+			style = ", style=bold"
+		}
+		fmt.Fprintf(sb, "  %q -> %q [label=%q%s];\n", e.From, e.To, e.Type, style)
+	}
+	sb.WriteString("}\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// WriteMermaid renders the graph as a mermaid flowchart, leafref edges
+// between the same resources are merged
+func (gr *Graph) WriteMermaid(w io.Writer) error {
+	ids := map[string]string{}
+	sb := &strings.Builder{}
+	sb.WriteString("flowchart LR\n")
+	for i, n := range gr.Nodes {
+		// mermaid ids cannot contain all characters of a path
+		ids[n.ID] = fmt.Sprintf("n%d", i)
+		if n.Kind == NodeKindUnmanaged {
+			fmt.Fprintf(sb, "  %s([\"%s\"])\n", ids[n.ID], n.Path)
+		} else {
+			fmt.Fprintf(sb, "  %s[\"%s<br/>%s\"]\n", ids[n.ID], n.Kind, n.Path)
+		}
+	}
+	for _, e := range gr.getUniqueEdges() {
+		arrow := "-->"
+		if e.Type != EdgeTypeParent {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(sb, "  %s %s|%s| %s\n", ids[e.From], arrow, e.Type, ids[e.To])
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// WriteJSON renders the graph as JSON with an edge per leafref
+func (gr *Graph) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(gr)
+}
+
+func (gr *Graph) getUniqueEdges() []*GraphEdge {
+	edges := make([]*GraphEdge, 0, len(gr.Edges))
+	seen := map[string]bool{}
+	for _, e := range gr.Edges {
+		key := e.From + "|" + e.To + "|" + e.Type
+		if !seen[key] {
+			seen[key] = true
+			edges = append(edges, e)
+		}
+	}
+	return edges
+}
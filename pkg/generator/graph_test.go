@@ -0,0 +1,171 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/yndd/ndd-runtime/pkg/logging"
+	"github.com/yndd/ndd-ygen/pkg/fsys"
+)
+
+// the local path of a static leafref is with or without the module, the target of the
+// second static leafref is not covered by a resource
+const testGraphResourceMap = `path:
+  /sample-interfaces/interfaces/interface:
+    excludes:
+      - /subinterface
+    hierarchy:
+      /subinterface:
+  /sample-network-instance/network-instances/network-instance:
+static-leafref:
+  /sample-network-instance/network-instances/network-instance/name: /sample-interfaces/interfaces/interface/description
+  /network-instances/network-instance/type: /system/name
+`
+
+const testGraphDOT = `digraph resources {
+  rankdir=LR;
+  node [shape=box];
+  "/system/name" [label="unmanaged\n/system/name", style=dashed];
+  "interfaces-interface" [label="SampleInterfacesInterface\n/sample-interfaces/interfaces/interface"];
+  "interfaces-interface-subinterface" [label="SampleInterfacesInterfaceSubinterface\n/sample-interfaces/interfaces/interface/subinterface"];
+  "networkinstances-networkinstance" [label="SampleNetworkinstancesNetworkinstance\n/sample-network-instance/network-instances/network-instance"];
+  "interfaces-interface-subinterface" -> "interfaces-interface" [label="parent", style=bold];
+  "networkinstances-networkinstance" -> "/system/name" [label="static-leafref"];
+  "networkinstances-networkinstance" -> "interfaces-interface" [label="leafref"];
+  "networkinstances-networkinstance" -> "interfaces-interface" [label="static-leafref"];
+  "networkinstances-networkinstance" -> "interfaces-interface-subinterface" [label="leafref"];
+}
+`
+
+const testGraphMermaid = `flowchart LR
+  n0(["/system/name"])
+  n1["SampleInterfacesInterface<br/>/sample-interfaces/interfaces/interface"]
+  n2["SampleInterfacesInterfaceSubinterface<br/>/sample-interfaces/interfaces/interface/subinterface"]
+  n3["SampleNetworkinstancesNetworkinstance<br/>/sample-network-instance/network-instances/network-instance"]
+  n2 -->|parent| n1
+  n3 -.->|static-leafref| n0
+  n3 -.->|leafref| n1
+  n3 -.->|static-leafref| n1
+  n3 -.->|leafref| n2
+`
+
+const testGraphJSON = `{
+  "nodes": [
+    {
+      "id": "/system/name",
+      "kind": "unmanaged",
+      "path": "/system/name"
+    },
+    {
+      "id": "interfaces-interface",
+      "kind": "SampleInterfacesInterface",
+      "path": "/sample-interfaces/interfaces/interface"
+    },
+    {
+      "id": "interfaces-interface-subinterface",
+      "kind": "SampleInterfacesInterfaceSubinterface",
+      "path": "/sample-interfaces/interfaces/interface/subinterface"
+    },
+    {
+      "id": "networkinstances-networkinstance",
+      "kind": "SampleNetworkinstancesNetworkinstance",
+      "path": "/sample-network-instance/network-instances/network-instance"
+    }
+  ],
+  "edges": [
+    {
+      "from": "interfaces-interface-subinterface",
+      "to": "interfaces-interface",
+      "type": "parent"
+    },
+    {
+      "from": "networkinstances-networkinstance",
+      "to": "/system/name",
+      "type": "static-leafref",
+      "localPath": "/network-instances/network-instance/type",
+      "remotePath": "/system/name"
+    },
+    {
+      "from": "networkinstances-networkinstance",
+      "to": "interfaces-interface",
+      "type": "leafref",
+      "localPath": "/name",
+      "remotePath": "/interfaces/interface[name=]"
+    },
+    {
+      "from": "networkinstances-networkinstance",
+      "to": "interfaces-interface",
+      "type": "static-leafref",
+      "localPath": "/sample-network-instance/network-instances/network-instance/name",
+      "remotePath": "/sample-interfaces/interfaces/interface/description"
+    },
+    {
+      "from": "networkinstances-networkinstance",
+      "to": "interfaces-interface-subinterface",
+      "type": "leafref",
+      "localPath": "/subinterface",
+      "remotePath": "/interfaces/interface/subinterface[index=]"
+    }
+  ]
+}
+`
+
+func TestGraph(t *testing.T) {
+	mapFile := filepath.Join(t.TempDir(), "map.yaml")
+	if err := os.WriteFile(mapFile, []byte(testGraphResourceMap), 0644); err != nil {
+		t.Fatal(err)
+	}
+	g, err := NewGenerator(
+		WithOutputFS(fsys.NewMemFS()),
+		WithLogging(logging.NewNopLogger()),
+		WithYangImportDirs([]string{filepath.Join(testdataDir, "yang")}),
+		WithYangModuleDirs([]string{filepath.Join(testdataDir, "yang")}),
+		WithResourceMapInputFile(mapFile),
+		WithPrefix("sample"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Run(); err != nil {
+		t.Fatal(err)
+	}
+	gr := g.GetGraph()
+
+	for format, want := range map[string]string{
+		GraphFormatDOT:     testGraphDOT,
+		GraphFormatMermaid: testGraphMermaid,
+		GraphFormatJSON:    testGraphJSON,
+	} {
+		t.Run(format, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			if err := gr.Write(buf, format); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+
+	if err := gr.Write(new(bytes.Buffer), "svg"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"sync"
+)
+
+// runJobs runs the jobs with a pool of n workers. The errors are returned in the
+// order of the jobs, such that the result does not depend on the scheduling.
+func runJobs(n int, jobs []func() error) error {
+	if n < 1 {
+		n = 1
+	}
+	if n > len(jobs) {
+		n = len(jobs)
+	}
+	jobErrs := make([]error, len(jobs))
+	idx := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < n; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range idx {
+				jobErrs[i] = jobs[i]()
+			}
+		}()
+	}
+	for i := range jobs {
+		idx <- i
+	}
+	close(idx)
+	wg.Wait()
+
+	var errs Errors
+	for _, err := range jobErrs {
+		if err != nil {
+			errs = appendError(errs, err)
+		}
+	}
+	return errs.ErrorOrNil()
+}
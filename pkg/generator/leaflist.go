@@ -0,0 +1,63 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"github.com/yndd/ndd-yang/pkg/container"
+)
+
+// setEntryLeafList records the leaf of a leaf-list on the container entry of the leaf-list,
+// the generator records a leaf-list as a container with the leaf for the yang schema
+func (g *Generator) setEntryLeafList(ce *container.Entry, leaf *container.Entry) {
+	g.entryLeafLists[ce] = leaf
+}
+
+// GetEntryLeafList returns the leaf of the leaf-list of the container entry, nil when the
+// container entry is not a leaf-list
+func (g *Generator) GetEntryLeafList(ce *container.Entry) *container.Entry {
+	return g.entryLeafLists[ce]
+}
+
+// getLeafListTypes returns the go types of the values of the leaf-lists in the container,
+// keyed by the name of the leaf-list
+func (g *Generator) getLeafListTypes(c *container.Container) map[string]string {
+	types := map[string]string{}
+	for _, e := range c.GetEntries() {
+		if leaf := g.GetEntryLeafList(e); leaf != nil {
+			types[e.GetName()] = leaf.GetType()
+		}
+	}
+	return types
+}
+
+// getResourceContainers returns the container and its children recursively which are
+// rendered as go structs, the leaf-lists are rendered as slices of their values
+func (g *Generator) getResourceContainers(c *container.Container) []*container.Container {
+	leafLists := map[*container.Container]bool{}
+	for _, e := range c.GetEntries() {
+		if g.GetEntryLeafList(e) != nil {
+			leafLists[e.GetNext()] = true
+		}
+	}
+	cs := []*container.Container{c}
+	for _, child := range c.GetChildren() {
+		if !leafLists[child] {
+			cs = append(cs, g.getResourceContainers(child)...)
+		}
+	}
+	return cs
+}
@@ -0,0 +1,340 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+const (
+	// manifestFileName is the name of the manifest in the output directory
+	manifestFileName = ".ndd-ygen-manifest.json"
+
+	errManifestUnmarshal = "cannot unmarshal manifest"
+)
+
+// the render kinds group the rendered files by the render that writes them, the files of
+// a render kind are written to the root directory of the render kind
+const (
+	renderKindResources   = "resources"
+	renderKindSchema      = "schema"
+	renderKindDeepCopy    = "deepcopy"
+	renderKindRFC7951     = "rfc7951"
+	renderKindControllers = "controllers"
+	renderKindCRDs        = "crds"
+	renderKindExamples    = "examples"
+)
+
+// renderedFile is a file that is rendered by a render kind
+type renderedFile struct {
+	kind string
+	b    []byte
+}
+
+// FileAction is the action a render applies to a file in the output directories
+type FileAction string
+
+const (
+	FileActionCreate FileAction = "create"
+	FileActionChange FileAction = "change"
+	FileActionRemove FileAction = "remove"
+)
+
+// FileChange is a file in the output directories that is created, changed or
+// removed by the render
+type FileChange struct {
+	Path     string
+	Action   FileAction
+	Current  []byte // the content of the file in the output directory
+	Rendered []byte // the rendered content of the file
+}
+
+// Diff returns the unified diff between the current and the rendered content of the file
+func (fc *FileChange) Diff() (string, error) {
+	fromFile, toFile := "a/"+fc.Path, "b/"+fc.Path
+	switch fc.Action {
+	case FileActionCreate:
+		fromFile = os.DevNull
+	case FileActionRemove:
+		toFile = os.DevNull
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(fc.Current),
+		B:        splitLines(fc.Rendered),
+		FromFile: fromFile,
+		ToFile:   toFile,
+		Context:  3,
+	})
+}
+
+// splitLines splits the content in lines that keep their newline, unlike difflib.SplitLines
+// the content does not end with an empty line and empty content has no lines
+func splitLines(b []byte) []string {
+	if len(b) == 0 {
+		return nil
+	}
+	lines := strings.SplitAfter(string(b), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// startRender records that the render kind is rendered, such that the files of the
+// previous render of the kind that are not rendered anymore are stale, also when
+// the render does not write any file
+func (g *Generator) startRender(kind string) {
+	g.renderedFilesMutex.Lock()
+	defer g.renderedFilesMutex.Unlock()
+	g.renderKinds[kind] = true
+}
+
+// getRenderKindRoot returns the root directory of the files of the render kind
+func (g *Generator) getRenderKindRoot(kind string) string {
+	switch kind {
+	case renderKindCRDs:
+		return g.GetConfig().GetCrdOutputDir()
+	case renderKindExamples:
+		return g.GetConfig().GetExampleOutputDir()
+	default:
+		return g.GetConfig().GetOutputDir()
+	}
+}
+
+// writeFile records the rendered file of the render kind and writes it to the output
+// directory, unless the generator runs in dry-run mode
+func (g *Generator) writeFile(kind, name string, b []byte) error {
+	g.renderedFilesMutex.Lock()
+	g.renderKinds[kind] = true
+	g.renderedFiles[filepath.Clean(name)] = &renderedFile{kind: kind, b: b}
+	g.renderedFilesMutex.Unlock()
+
+	if g.GetDryRun() {
+		return nil
+	}
+	if err := g.GetOutputFS().MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return err
+	}
+	return g.GetOutputFS().WriteFile(name, b, 0644)
+}
+
+// GetFileChanges compares the rendered files with the files in the output directories.
+// The files of the previous render, which are not rendered anymore, are reported
+// as removed. The changes are sorted by path.
+func (g *Generator) GetFileChanges() ([]*FileChange, error) {
+	stale, err := g.getStaleFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	g.renderedFilesMutex.Lock()
+	defer g.renderedFilesMutex.Unlock()
+
+	changes := make([]*FileChange, 0)
+	for name, rf := range g.renderedFiles {
+		current, err := g.GetOutputFS().ReadFile(name)
+		switch {
+		case os.IsNotExist(err):
+			changes = append(changes, &FileChange{Path: name, Action: FileActionCreate, Rendered: rf.b})
+		case err != nil:
+			return nil, err
+		case !bytes.Equal(current, rf.b):
+			changes = append(changes, &FileChange{Path: name, Action: FileActionChange, Current: current, Rendered: rf.b})
+		}
+	}
+	for _, name := range stale {
+		current, err := g.GetOutputFS().ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, &FileChange{Path: name, Action: FileActionRemove, Current: current})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes, nil
+}
+
+// Manifest lists the files the generator rendered, it is written to the output directory
+// such that the next render can remove the files that are not rendered anymore
+type Manifest struct {
+	Files []ManifestFile `json:"files"`
+}
+
+// ManifestFile is a rendered file with a path relative to the root directory of the
+// render kind, the path of a file without a kind is relative to the output directory
+type ManifestFile struct {
+	Kind string `json:"kind,omitempty"`
+	Path string `json:"path"`
+	Hash string `json:"hash"`
+}
+
+func getHash(b []byte) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(b))
+}
+
+func (g *Generator) getManifestPath() string {
+	return filepath.Join(g.GetConfig().GetOutputDir(), manifestFileName)
+}
+
+// readManifest reads the manifest of the previous render, the manifest is
+// empty when the output directory does not have a manifest
+func (g *Generator) readManifest() (*Manifest, error) {
+	m := &Manifest{}
+	b, err := g.GetOutputFS().ReadFile(g.getManifestPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return m, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(b, m); err != nil {
+		return nil, errors.Wrapf(err, "%s %s", errManifestUnmarshal, g.getManifestPath())
+	}
+	return m, nil
+}
+
+// getManifestFileName returns the path of the file of the manifest
+func (g *Generator) getManifestFileName(mf ManifestFile) string {
+	return filepath.Join(g.getRenderKindRoot(mf.Kind), filepath.FromSlash(mf.Path))
+}
+
+// isRenderedManifestFile returns true when the render kind of the file of the manifest
+// is rendered, the files of a manifest without render kinds are scoped by the directories
+// the render writes to. The renderedFilesMutex must be held.
+func (g *Generator) isRenderedManifestFile(mf ManifestFile) bool {
+	if mf.Kind != "" {
+		return g.renderKinds[mf.Kind]
+	}
+	dir := filepath.Dir(g.getManifestFileName(mf))
+	for name := range g.renderedFiles {
+		if filepath.Dir(name) == dir {
+			return true
+		}
+	}
+	return false
+}
+
+// getStaleFiles returns the files of the previous render of the render kinds that are
+// rendered and which are not rendered anymore. Files that are modified since the
+// previous render are not considered stale.
+func (g *Generator) getStaleFiles() ([]string, error) {
+	m, err := g.readManifest()
+	if err != nil {
+		return nil, err
+	}
+
+	g.renderedFilesMutex.Lock()
+	defer g.renderedFilesMutex.Unlock()
+	stale := make([]string, 0)
+	for _, mf := range m.Files {
+		name := g.getManifestFileName(mf)
+		if _, ok := g.renderedFiles[name]; ok || !g.isRenderedManifestFile(mf) {
+			continue
+		}
+		b, err := g.GetOutputFS().ReadFile(name)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		if getHash(b) != mf.Hash {
+			g.log.Debug("generated file is modified, not removing it", "file", name)
+			continue
+		}
+		stale = append(stale, name)
+	}
+	sort.Strings(stale)
+	return stale, nil
+}
+
+// RemoveStaleFiles removes the files of the previous render that are not rendered anymore.
+// Only the files listed in the manifest of the output directory are removed, hence files
+// that are not rendered by the generator are left untouched.
+func (g *Generator) RemoveStaleFiles() error {
+	if g.GetDryRun() {
+		return nil
+	}
+	stale, err := g.getStaleFiles()
+	if err != nil {
+		return err
+	}
+	for _, name := range stale {
+		g.log.Debug("remove stale file", "file", name)
+		if err := g.GetOutputFS().Remove(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteManifest writes the manifest with the rendered files to the output directory.
+// The files of the previous render of the render kinds that are not rendered are kept,
+// e.g. the schema files when rendering the resources in the same output directory.
+func (g *Generator) WriteManifest() error {
+	if g.GetDryRun() {
+		return nil
+	}
+	m, err := g.readManifest()
+	if err != nil {
+		return err
+	}
+
+	g.renderedFilesMutex.Lock()
+	newManifest := &Manifest{Files: make([]ManifestFile, 0, len(m.Files)+len(g.renderedFiles))}
+	for _, mf := range m.Files {
+		if !g.isRenderedManifestFile(mf) {
+			newManifest.Files = append(newManifest.Files, mf)
+		}
+	}
+	for name, rf := range g.renderedFiles {
+		rel, err := filepath.Rel(g.getRenderKindRoot(rf.kind), name)
+		if err != nil {
+			g.renderedFilesMutex.Unlock()
+			return err
+		}
+		newManifest.Files = append(newManifest.Files, ManifestFile{Kind: rf.kind, Path: filepath.ToSlash(rel), Hash: getHash(rf.b)})
+	}
+	g.renderedFilesMutex.Unlock()
+
+	sort.Slice(newManifest.Files, func(i, j int) bool {
+		if newManifest.Files[i].Kind != newManifest.Files[j].Kind {
+			return newManifest.Files[i].Kind < newManifest.Files[j].Kind
+		}
+		return newManifest.Files[i].Path < newManifest.Files[j].Path
+	})
+	b, err := json.MarshalIndent(newManifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := g.GetOutputFS().MkdirAll(g.GetConfig().GetOutputDir(), 0755); err != nil {
+		return err
+	}
+	return g.GetOutputFS().WriteFile(g.getManifestPath(), append(b, '\n'), 0644)
+}
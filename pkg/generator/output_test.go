@@ -0,0 +1,311 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yndd/ndd-runtime/pkg/logging"
+	"github.com/yndd/ndd-ygen/pkg/fsys"
+)
+
+const testOutputResourceMap = `path:
+  /test-system/system/server:
+  /test-choice/routes/route:
+`
+
+// renderTestOutput renders the resources, the controllers and the crds of the resource map
+// to the output filesystem, the crds are written outside of the output directory
+func renderTestOutput(t *testing.T, outputFS fsys.WriteFS, resourceMap string, controllers, dryRun bool) *Generator {
+	t.Helper()
+	inputFS := fsys.NewMemFS()
+	for name, data := range map[string]string{
+		"yang/test-system.yang":     testSystemYang,
+		"yang/test-system-ext.yang": testSystemExtYang,
+		"yang/test-choice.yang":     testChoiceYang,
+		"map.yaml":                  resourceMap,
+	} {
+		if err := inputFS.WriteFile(name, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	g, err := NewGenerator(
+		WithInputFS(inputFS),
+		WithOutputFS(outputFS),
+		WithLogging(logging.NewNopLogger()),
+		WithYangModuleDirs([]string{"yang"}),
+		WithResourceMapInputFile("map.yaml"),
+		WithOutputDir("out"),
+		WithCrdOutputDir("config/crd"),
+		WithVersion("v1alpha1"),
+		WithAPIGroup("test.ndd.yndd.io"),
+		WithPrefix("test"),
+		WithGoModule("github.com/yndd/test"),
+		WithDryRun(dryRun),
+		WithLocalRender(true),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Run(); err != nil {
+		t.Fatal(err)
+	}
+	renders := []func() error{g.Render, g.RenderCRDs}
+	if controllers {
+		renders = append(renders, g.RenderRFC7951, g.RenderControllers)
+	}
+	for _, render := range renders {
+		if err := render(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return g
+}
+
+// writeTestOutput removes the stale files and writes the manifest
+func writeTestOutput(t *testing.T, g *Generator) {
+	t.Helper()
+	if err := g.RemoveStaleFiles(); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.WriteManifest(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func getTestFileChanges(t *testing.T, g *Generator) map[string]FileAction {
+	t.Helper()
+	changes, err := g.GetFileChanges()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]FileAction{}
+	for _, c := range changes {
+		got[c.Path] = c.Action
+	}
+	return got
+}
+
+var testOutputFiles = []string{
+	"config/crd/test.ndd.yndd.io_testroutesroutes.yaml",
+	"config/crd/test.ndd.yndd.io_testsystemservers.yaml",
+	"out/.ndd-ygen-manifest.json",
+	"out/apis/v1alpha1/groupversion_info.go",
+	"out/apis/v1alpha1/test_routes_route_types.go",
+	"out/apis/v1alpha1/test_system_server_types.go",
+	"out/apis/v1alpha1/zz_generated.managed.go",
+	"out/apis/v1alpha1/zz_generated.rfc7951.go",
+	"out/internal/controllers/controllers.go",
+	"out/internal/controllers/routesroute/routesroute.go",
+	"out/internal/controllers/systemserver/systemserver.go",
+}
+
+func TestRemoveStaleFiles(t *testing.T) {
+	const serverMap = "path:\n  /test-system/system/server:\n"
+
+	cases := map[string]struct {
+		// modifies the output of the first render
+		modify      func(t *testing.T, outputFS *fsys.MemFS)
+		controllers bool
+		want        []string
+		// the files after rendering the controllers again, defaults to want
+		wantRerender []string
+	}{
+		"RemovedResource": {
+			controllers: true,
+			want: []string{
+				"config/crd/test.ndd.yndd.io_testsystemservers.yaml",
+				"out/.ndd-ygen-manifest.json",
+				"out/apis/v1alpha1/groupversion_info.go",
+				"out/apis/v1alpha1/test_system_server_types.go",
+				"out/apis/v1alpha1/zz_generated.managed.go",
+				"out/apis/v1alpha1/zz_generated.rfc7951.go",
+				"out/internal/controllers/controllers.go",
+				"out/internal/controllers/systemserver/systemserver.go",
+			},
+		},
+		"ModifiedFileIsKept": {
+			modify: func(t *testing.T, outputFS *fsys.MemFS) {
+				if err := outputFS.WriteFile("out/internal/controllers/routesroute/routesroute.go", []byte("package routesroute\n"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			},
+			controllers: true,
+			want: []string{
+				"config/crd/test.ndd.yndd.io_testsystemservers.yaml",
+				"out/.ndd-ygen-manifest.json",
+				"out/apis/v1alpha1/groupversion_info.go",
+				"out/apis/v1alpha1/test_system_server_types.go",
+				"out/apis/v1alpha1/zz_generated.managed.go",
+				"out/apis/v1alpha1/zz_generated.rfc7951.go",
+				"out/internal/controllers/controllers.go",
+				"out/internal/controllers/routesroute/routesroute.go",
+				"out/internal/controllers/systemserver/systemserver.go",
+			},
+		},
+		"RenderKindNotRenderedIsKept": {
+			controllers: false,
+			want: []string{
+				"config/crd/test.ndd.yndd.io_testsystemservers.yaml",
+				"out/.ndd-ygen-manifest.json",
+				"out/apis/v1alpha1/groupversion_info.go",
+				"out/apis/v1alpha1/test_system_server_types.go",
+				"out/apis/v1alpha1/zz_generated.managed.go",
+				"out/apis/v1alpha1/zz_generated.rfc7951.go",
+				"out/internal/controllers/controllers.go",
+				"out/internal/controllers/routesroute/routesroute.go",
+				"out/internal/controllers/systemserver/systemserver.go",
+			},
+			// the controllers of the first render are still tracked by the manifest
+			wantRerender: []string{
+				"config/crd/test.ndd.yndd.io_testsystemservers.yaml",
+				"out/.ndd-ygen-manifest.json",
+				"out/apis/v1alpha1/groupversion_info.go",
+				"out/apis/v1alpha1/test_system_server_types.go",
+				"out/apis/v1alpha1/zz_generated.managed.go",
+				"out/apis/v1alpha1/zz_generated.rfc7951.go",
+				"out/internal/controllers/controllers.go",
+				"out/internal/controllers/systemserver/systemserver.go",
+			},
+		},
+		"UserFileIsKept": {
+			modify: func(t *testing.T, outputFS *fsys.MemFS) {
+				if err := outputFS.WriteFile("out/internal/controllers/routesroute/custom.go", []byte("package routesroute\n"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			},
+			controllers: true,
+			want: []string{
+				"config/crd/test.ndd.yndd.io_testsystemservers.yaml",
+				"out/.ndd-ygen-manifest.json",
+				"out/apis/v1alpha1/groupversion_info.go",
+				"out/apis/v1alpha1/test_system_server_types.go",
+				"out/apis/v1alpha1/zz_generated.managed.go",
+				"out/apis/v1alpha1/zz_generated.rfc7951.go",
+				"out/internal/controllers/controllers.go",
+				"out/internal/controllers/routesroute/custom.go",
+				"out/internal/controllers/systemserver/systemserver.go",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			outputFS := fsys.NewMemFS()
+			writeTestOutput(t, renderTestOutput(t, outputFS, testOutputResourceMap, true, false))
+			if got := outputFS.GetFileNames(); !reflect.DeepEqual(got, testOutputFiles) {
+				t.Fatalf("rendered files:\ngot:  %v\nwant: %v", got, testOutputFiles)
+			}
+			if tc.modify != nil {
+				tc.modify(t, outputFS)
+			}
+
+			writeTestOutput(t, renderTestOutput(t, outputFS, serverMap, tc.controllers, false))
+			if got := outputFS.GetFileNames(); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("files after removing the route resource:\ngot:  %v\nwant: %v", got, tc.want)
+			}
+
+			want := tc.wantRerender
+			if want == nil {
+				want = tc.want
+			}
+			writeTestOutput(t, renderTestOutput(t, outputFS, serverMap, true, false))
+			if got := outputFS.GetFileNames(); !reflect.DeepEqual(got, want) {
+				t.Errorf("files after rendering the controllers again:\ngot:  %v\nwant: %v", got, want)
+			}
+		})
+	}
+}
+
+func TestGetFileChangesDryRun(t *testing.T) {
+	outputFS := fsys.NewMemFS()
+
+	// a dry-run reports the files that are created without writing them
+	g := renderTestOutput(t, outputFS, testOutputResourceMap, true, true)
+	writeTestOutput(t, g)
+	if got := outputFS.GetFileNames(); len(got) != 0 {
+		t.Errorf("dry-run wrote files: %v", got)
+	}
+	got := getTestFileChanges(t, g)
+	want := map[string]FileAction{}
+	for _, name := range testOutputFiles {
+		if !strings.HasSuffix(name, manifestFileName) {
+			want[name] = FileActionCreate
+		}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("dry-run changes of the first render:\ngot:  %v\nwant: %v", got, want)
+	}
+
+	writeTestOutput(t, renderTestOutput(t, outputFS, testOutputResourceMap, true, false))
+	if err := outputFS.WriteFile("out/apis/v1alpha1/test_system_server_types.go", []byte("package v1alpha1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// the modified file is changed back and the files of the removed resource are removed
+	g = renderTestOutput(t, outputFS, "path:\n  /test-system/system/server:\n", true, true)
+	got = getTestFileChanges(t, g)
+	want = map[string]FileAction{
+		"config/crd/test.ndd.yndd.io_testroutesroutes.yaml":   FileActionRemove,
+		"out/apis/v1alpha1/groupversion_info.go":              FileActionChange,
+		"out/apis/v1alpha1/test_routes_route_types.go":        FileActionRemove,
+		"out/apis/v1alpha1/test_system_server_types.go":       FileActionChange,
+		"out/apis/v1alpha1/zz_generated.managed.go":           FileActionChange,
+		"out/apis/v1alpha1/zz_generated.rfc7951.go":           FileActionChange,
+		"out/internal/controllers/controllers.go":             FileActionChange,
+		"out/internal/controllers/routesroute/routesroute.go": FileActionRemove,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("dry-run changes after removing the route resource:\ngot:  %v\nwant: %v", got, want)
+	}
+	writeTestOutput(t, g)
+	if got := outputFS.GetFileNames(); !reflect.DeepEqual(got, testOutputFiles) {
+		t.Errorf("dry-run changed the files:\ngot:  %v\nwant: %v", got, testOutputFiles)
+	}
+}
+
+func TestFileChangeDiff(t *testing.T) {
+	cases := map[string]struct {
+		change *FileChange
+		want   string
+	}{
+		"Create": {
+			change: &FileChange{Path: "out/a.go", Action: FileActionCreate, Rendered: []byte("package a\n")},
+			want:   "--- /dev/null\n+++ b/out/a.go\n@@ -0,0 +1 @@\n+package a\n",
+		},
+		"Change": {
+			change: &FileChange{Path: "out/a.go", Action: FileActionChange, Current: []byte("package a\n\nvar x = 1\n"), Rendered: []byte("package a\n\nvar x = 2\n")},
+			want:   "--- a/out/a.go\n+++ b/out/a.go\n@@ -1,3 +1,3 @@\n package a\n \n-var x = 1\n+var x = 2\n",
+		},
+		"Remove": {
+			change: &FileChange{Path: "out/a.go", Action: FileActionRemove, Current: []byte("package a\n")},
+			want:   "--- a/out/a.go\n+++ /dev/null\n@@ -1 +0,0 @@\n-package a\n",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := tc.change.Diff()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("Diff:\ngot:\n%s\nwant:\n%s", got, tc.want)
+			}
+		})
+	}
+}
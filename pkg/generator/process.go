@@ -0,0 +1,105 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/pkg/errors"
+)
+
+const (
+	errProcessYang = "cannot process yang modules"
+
+	// the errors goyang returns without location when an import or include cannot be resolved
+	goyangNoSuchModule    = "no such module: "
+	goyangNoSuchSubmodule = "no such submodule: "
+)
+
+// processModules processes the yang modules and collects the errors of goyang. The
+// errors of unresolved imports and includes are returned, since goyang stops processing
+// the modules and the schema would be incomplete. The other errors are returned in strict
+// mode, otherwise they are logged and collected as warnings.
+func (g *Generator) processModules(moduleSet *yang.Modules) error {
+	var errs Errors
+	for _, err := range moduleSet.Process() {
+		err, unresolved := locateProcessError(moduleSet, err)
+		if unresolved || g.GetConfig().GetStrict() {
+			errs = appendError(errs, err)
+			continue
+		}
+		g.log.Info("yang processing warning", "warning", err.Error())
+		g.warnings = append(g.warnings, err)
+	}
+	if err := errs.ErrorOrNil(); err != nil {
+		return errors.Wrap(err, errProcessYang)
+	}
+	return nil
+}
+
+// locateProcessError adds the location of the import or include statements to the errors
+// of unresolved modules, the other errors of goyang hold the location already. It returns
+// true if the error is an unresolved import or include.
+func locateProcessError(moduleSet *yang.Modules, err error) (error, bool) {
+	msg := err.Error()
+	var name string
+	var imports bool
+	switch {
+	case strings.HasPrefix(msg, goyangNoSuchModule):
+		name, imports = strings.TrimPrefix(msg, goyangNoSuchModule), true
+	case strings.HasPrefix(msg, goyangNoSuchSubmodule):
+		name = strings.TrimPrefix(msg, goyangNoSuchSubmodule)
+	default:
+		return err, false
+	}
+
+	locations := map[string]bool{}
+	for _, mods := range []map[string]*yang.Module{moduleSet.Modules, moduleSet.SubModules} {
+		for _, m := range mods {
+			if imports {
+				for _, i := range m.Import {
+					if i.Name == name {
+						locations[yang.Source(i)] = true
+					}
+				}
+				continue
+			}
+			for _, i := range m.Include {
+				if i.Name == name {
+					locations[yang.Source(i)] = true
+				}
+			}
+		}
+	}
+	if len(locations) == 0 {
+		return err, true
+	}
+	sources := make([]string, 0, len(locations))
+	for source := range locations {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+	return errors.Errorf("%s: %s", strings.Join(sources, ", "), msg), true
+}
+
+// GetWarnings returns the errors of goyang that are ignored, since the generator
+// does not run in strict mode
+func (g *Generator) GetWarnings() []error {
+	return g.warnings
+}
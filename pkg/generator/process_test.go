@@ -0,0 +1,110 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yndd/ndd-runtime/pkg/logging"
+	"github.com/yndd/ndd-ygen/pkg/fsys"
+)
+
+const testMissingImportYang = `module test-missing {
+  namespace "urn:test:missing";
+  prefix tm;
+  import test-nope { prefix tn; }
+
+  container top {
+    leaf name {
+      type string;
+    }
+  }
+}
+`
+
+const testBrokenAugmentYang = `module test-augment {
+  namespace "urn:test:augment";
+  prefix ta;
+
+  container top {
+    leaf name {
+      type string;
+    }
+  }
+  augment "/ta:nope" {
+    leaf name {
+      type string;
+    }
+  }
+}
+`
+
+func TestProcessModules(t *testing.T) {
+	cases := map[string]struct {
+		yang         string
+		strict       bool
+		wantErr      string
+		wantWarnings int
+	}{
+		"UnresolvedImport": {
+			yang:    testMissingImportYang,
+			wantErr: "yang/test.yang:4:3: no such module: test-nope",
+		},
+		"Warning": {
+			yang:         testBrokenAugmentYang,
+			wantWarnings: 1,
+		},
+		"Strict": {
+			yang:    testBrokenAugmentYang,
+			strict:  true,
+			wantErr: "yang/test.yang:10:3: augment /ta:nope not found",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			inputFS := fsys.NewMemFS()
+			if err := inputFS.WriteFile("yang/test.yang", []byte(tc.yang), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := inputFS.WriteFile("map.yaml", []byte("path:\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			g, err := NewGenerator(
+				WithInputFS(inputFS),
+				WithOutputFS(fsys.NewMemFS()),
+				WithLogging(logging.NewNopLogger()),
+				WithYangModuleDirs([]string{"yang"}),
+				WithResourceMapInputFile("map.yaml"),
+				WithStrict(tc.strict),
+			)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := len(g.GetWarnings()); got != tc.wantWarnings {
+				t.Errorf("warnings: got %d, want %d: %v", got, tc.wantWarnings, g.GetWarnings())
+			}
+		})
+	}
+}
@@ -17,131 +17,49 @@ limitations under the License.
 package generator
 
 import (
-	"fmt"
 	"path/filepath"
 	"sort"
 	"strings"
 
 	"github.com/openconfig/gnmi/proto/gnmi"
 	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/pkg/errors"
 	"github.com/yndd/ndd-yang/pkg/container"
 	"github.com/yndd/ndd-yang/pkg/resource"
 	"github.com/yndd/ndd-yang/pkg/yparser"
 )
 
+const (
+	errParentContainerNotFound = "cannot find the parent container"
+	errResourceRootLeaf        = "a resource cannot start at a leaf"
+)
+
 func (g *Generator) GetModuleName(namespace string) string {
-	for moduleName, m := range g.getModules() {
+	// the modules are indexed by name and by name@revision
+	for _, m := range g.getModules() {
 		if m.Namespace.Name == namespace {
-			return moduleName
+			return m.Name
 		}
 	}
 	return ""
 }
 
 func (g *Generator) IsResourceBoundary(respath string) bool {
-	inputPath := yparser.Xpath2GnmiPath(respath, 0)
-	for _, r := range g.GetResources()[1:] {
-		//fmt.Printf("resource Path: %s\n", *r.GetAbsoluteXPath())
-		// if the input path is smaller than the resource we know there is no match
-		if len(r.GetAbsolutePath().GetElem()) == len(inputPath.GetElem()) {
-			found := true
-			for i, PathElem := range r.GetAbsolutePath().GetElem() {
-				// if the name of the PathElem don't match this is not a resource that matches
-				if PathElem.GetName() != inputPath.GetElem()[i].GetName() {
-					found = false
-					break
-				}
-			}
-			// if found we can return, Since we found an exact match
-			if found {
-				//fmt.Printf("resource boundary Path: %s\n", respath)
-				return true
-			}
-		}
-	}
-	return false
+	return g.resourceTrie.isResourceBoundary(yparser.Xpath2GnmiPath(respath, 0))
 }
 
 func (g *Generator) GetActualResources() []*resource.Resource {
 	return g.GetResources()
 }
 
-// FindBestMatchfinds the resource that has the best match, otherwise the resource is not found
+// FindBestMatch finds the resource that has the best match, otherwise the resource is not found
 // it uses the pathElem names to compare between the resource path and the input path
 func (g *Generator) FindBestMatch(inputPath *gnmi.Path) (*resource.Resource, bool) {
-	minLength := 0
-	resMatch := &resource.Resource{}
-	found := false
-
-	// loop over all resources depending on the scenario
-	// option 1: for full resources it is the first element
-	// option 2: for individual resources it is all except the first resource
-	for _, r := range g.GetActualResources()[1:] {
-		// if the input path is smaller than the resource we know there is no match
-		//fmt.Printf("len r: %d, len ip: %d\n", len(r.GetAbsolutePath().GetElem()), len(inputPath.GetElem()))
-		if len(r.GetAbsolutePath().GetElem()) <= len(inputPath.GetElem()) {
-			found = true
-			//fmt.Printf("FindBestMatch: resPath: %s, inputPath: %s\n", yparser.GnmiPath2XPath(r.GetAbsolutePath(), false), yparser.GnmiPath2XPath(inputPath, false))
-			// given we know the input PathElem are >= the resource Elements we can compare
-			// the elements using the index of the resource PathElem
-			for i, PathElem := range r.GetAbsolutePath().GetElem() {
-				// if the name of the PathElem don't match this is not a resource that matches
-				if PathElem.GetName() != inputPath.GetElem()[i].GetName() {
-					found = false
-					break
-				}
-			}
-
-			// if the PathElem are bigger than the previously found this is a better match
-			if found && len(r.GetAbsolutePath().GetElem()) > minLength {
-				resMatch = r
-				minLength = len(r.GetAbsolutePath().GetElem())
-			}
-
-			/*
-				if strings.Contains(yparser.GnmiPath2XPath(inputPath, false), "/srl_nokia-network-instance/network-instance/aggregate-routes") {
-					if found {
-						fmt.Printf("FindBestMatchNew: inputPath: %s, resPath: %s, length: %d, found: %t\n", yparser.GnmiPath2XPath(inputPath, false), yparser.GnmiPath2XPath(resMatch.GetAbsolutePath(), false), minLength, found)
-						fmt.Printf("resMatch Path: %s\n", yparser.GnmiPath2XPath(resMatch.Path, false))
-					}
-
-				}
-			*/
-
-		}
-	}
-
-	if resMatch.Path != nil {
-		/*
-			if strings.Contains(yparser.GnmiPath2XPath(inputPath, false), "/srl_nokia-network-instance/network-instance/aggregate-routes") {
-				fmt.Printf("FindBestMatchNew: inputPath: %s, resPath: %s, length: %d, found: %t\n", yparser.GnmiPath2XPath(inputPath, false), yparser.GnmiPath2XPath(resMatch.GetAbsolutePath(), false), minLength, found)
-			}
-		*/
-		return resMatch, true
-	}
-	return resMatch, false
-}
-
-func (g *Generator) ifExcluded(path *gnmi.Path, excludePaths []*gnmi.Path) bool {
-	for _, exclPath := range excludePaths {
-		//fmt.Printf("Excluded Path : %s\n", *g.parser.GnmiPathToXPath(exclPath, true))
-		// if the length of the path is less than the exclude path there is no exclusion
-		if len(path.GetElem()) >= len(exclPath.GetElem()) {
-			found := false
-			for i, exlPathElem := range exclPath.GetElem() {
-				if exlPathElem.GetName() != path.GetElem()[i].GetName() {
-					found = false
-					break
-				}
-				found = true
-			}
-			// when all the PathElem matches this path of the tree is excluded
-			if found {
-				return true
-			}
-		}
+	r, _, ok := g.resourceTrie.findBestMatch(inputPath)
+	if !ok {
+		return &resource.Resource{}, false
 	}
-	return false
+	return r, true
 }
 
 // IsResourcesInit checks if the resource is part of the resource table and if no excludes exist
@@ -159,20 +77,11 @@ func (g *Generator) DoesResourceMatch(path *gnmi.Path) (*resource.Resource, bool
 
 	} else {
 		// this is the regular case
-		if r, ok := g.FindBestMatch(path); ok {
-
-			//fmt.Printf("match path: %s \n", yparser.GnmiPath2XPath(r.GetAbsolutePath(), false))
+		if r, excluded, ok := g.resourceTrie.findBestMatch(path); ok {
 			// check excludes
-
-			if g.ifExcluded(path, r.Excludes) {
+			if excluded {
 				return r, false
 			}
-			/*
-				if strings.Contains(yparser.GnmiPath2XPath(r.GetAbsolutePath(), false), "/network-instance/aggregate-routes") {
-					fmt.Printf("FindBestMatchNew: path: %s, resPath: %s\n", yparser.GnmiPath2XPath(path, false), yparser.GnmiPath2XPath(r.GetAbsolutePath(), false))
-				}
-			*/
-
 			return r, true
 		}
 		return nil, false
@@ -247,6 +156,10 @@ func (g *Generator) ResourceGenerator(resPath string, dynPath *gnmi.Path, e *yan
 					if newLevel > 0 {
 						r.ContainerLevel = newLevel
 
+						// the parent container is not initialized, e.g. the parent entry was not processed
+						if len(r.ContainerLevelKeys[newLevel-1]) == 0 {
+							return errors.Wrap(errors.New(errParentContainerNotFound), resPath)
+						}
 						cPtr = r.ContainerLevelKeys[newLevel-1][len(r.ContainerLevelKeys[newLevel-1])-1]
 
 						/*
@@ -280,7 +193,9 @@ func (g *Generator) ResourceGenerator(resPath string, dynPath *gnmi.Path, e *yan
 							r.SetRootContainerEntry(yparser.CreateContainerEntry(e, nil, nil, containerKey))
 							// added for full schema
 							if g.GetConfig().GetResourceMapAll() {
-								r.RootContainer.Entries = append(r.RootContainer.Entries, yparser.CreateContainerEntry(e, c, c, containerKey))
+								centry := yparser.CreateContainerEntry(e, c, c, containerKey)
+								g.setEntryChoiceCases(centry, e)
+								r.RootContainer.Entries = append(r.RootContainer.Entries, centry)
 							}
 							r.ContainerLevelKeys[newLevel] = make([]*container.Container, 0)
 							r.ContainerLevelKeys[newLevel] = append(r.ContainerLevelKeys[newLevel], c)
@@ -295,7 +210,9 @@ func (g *Generator) ResourceGenerator(resPath string, dynPath *gnmi.Path, e *yan
 							}
 							// allocate container entry to the original container Pointer and append to the container entry list
 							// the next pointer of the entry points to the new container
-							cPtr.Entries = append(cPtr.Entries, yparser.CreateContainerEntry(e, c, cPtr, containerKey))
+							centry := yparser.CreateContainerEntry(e, c, cPtr, containerKey)
+							g.setEntryChoiceCases(centry, e)
+							cPtr.Entries = append(cPtr.Entries, centry)
 							// append the container Ptr to the back of the list, to track the used container Pointers per level
 							// initialize the level
 							r.ContainerLevelKeys[newLevel] = make([]*container.Container, 0)
@@ -303,6 +220,10 @@ func (g *Generator) ResourceGenerator(resPath string, dynPath *gnmi.Path, e *yan
 							r.ContainerList = append(r.ContainerList, c)
 						}
 					} else { // // Leaf processing
+						// a leaf cannot be the root of a resource, since there is no container to attach it to
+						if cPtr == nil {
+							return errors.Wrap(errors.New(errResourceRootLeaf), resPath)
+						}
 						//fmt.Printf("State Info leaf: state info: %t entry name: %s \n", e.ReadOnly(), e.Name)
 						//fmt.Printf("Leaf Name: %s, ResPath: %s \n", e.Name, resPath)
 						//fmt.Printf("Entry: Name: %s, Dir: %#v, Type: %v, Units: %s, List: %v\n", e.Name, e.Dir, g.parser.GetTypeName(e), e.Units, e.ListAttr)
@@ -322,6 +243,7 @@ func (g *Generator) ResourceGenerator(resPath string, dynPath *gnmi.Path, e *yan
 							cPtr.AddContainerChild(c)
 							r.ContainerList = append(r.ContainerList, c)
 							centry := yparser.CreateContainerEntry(dummyYangEntry, c, cPtr, containerKey)
+							g.setEntryChoiceCases(centry, e)
 							cPtr.Entries = append(cPtr.Entries, centry)
 							if centry.GetDefault() != "" {
 								//fmt.Printf("container: %s, entry name: %s, default: %s\n", cPtr.GetFullName(), centry.GetName(), centry.GetDefault())
@@ -329,16 +251,20 @@ func (g *Generator) ResourceGenerator(resPath string, dynPath *gnmi.Path, e *yan
 							}
 
 							e.ListAttr = nil
-							centry = yparser.CreateContainerEntry(e, nil, nil, containerKey)
-							c.Entries = append(c.Entries, centry)
-							if centry.GetDefault() != "" {
-								//fmt.Printf("container: %s, entry name: %s, default: %s\n", c.GetFullName(), centry.GetName(), centry.GetDefault())
-								cPtr.SetDefault(e.Name, centry.GetDefault())
+							leaf := yparser.CreateContainerEntry(e, nil, nil, containerKey)
+							g.setEntryYangType(leaf, e)
+							g.setEntryLeafList(centry, leaf)
+							c.Entries = append(c.Entries, leaf)
+							if leaf.GetDefault() != "" {
+								//fmt.Printf("container: %s, entry name: %s, default: %s\n", c.GetFullName(), leaf.GetName(), leaf.GetDefault())
+								cPtr.SetDefault(e.Name, leaf.GetDefault())
 							}
 
 						} else {
 							// add entry to the container, containerKey allows to see if a
 							centry := yparser.CreateContainerEntry(e, nil, nil, containerKey)
+							g.setEntryChoiceCases(centry, e)
+							g.setEntryYangType(centry, e)
 							cPtr.Entries = append(cPtr.Entries, centry)
 							if centry.GetDefault() != "" {
 								//fmt.Printf("container: %s, entry name: %s, default: %s\n", cPtr.GetFullName(), centry.GetName(), centry.GetDefault())
@@ -359,7 +285,7 @@ func (g *Generator) ResourceGenerator(resPath string, dynPath *gnmi.Path, e *yan
 							localPath, remotePath, _ = yparser.ProcessLeafRef(e, resPath, r.GetAbsoluteGnmiPathFromSource())
 							if localPath != nil {
 								// validate if the leafrefs is a local leafref or an external leafref
-								fmt.Printf("LocalLeafRef localPath: %s, RemotePath: %s\n", yparser.GnmiPath2XPath(localPath, false), yparser.GnmiPath2XPath(remotePath, false))
+								g.log.Debug("leafref", "localPath", yparser.GnmiPath2XPath(localPath, false), "remotePath", yparser.GnmiPath2XPath(remotePath, false))
 								cPtr.AddLeafRef(localPath, remotePath)
 								centry.AddLeafref(remotePath)
 							}
@@ -384,16 +310,40 @@ func (g *Generator) ResourceGenerator(resPath string, dynPath *gnmi.Path, e *yan
 		names = append(names, k)
 	}
 	sort.Strings(names)
+	// the errors of the subtrees are collected such that all offending paths are reported
+	var errs Errors
 	for _, k := range names {
 		// 1/ the choice is supplied to the next level in order to ignore 1 more path from the tree
 		// 2. e.key is supplied to the next iteration as this identifies the key that is used at the containerlevel
 		// the key is resolved with the name in the next level resolution and this is how we can identify
 		// if a entry (which is the key name) is mandatory or not
-		var err error
-		if err = g.ResourceGenerator(resPath, newdynPath, e.Dir[k], e.IsChoice(), e.Key, e.Namespace().Name); err != nil {
-			return nil
+		if err := g.ResourceGenerator(resPath, newdynPath, e.Dir[k], e.IsChoice(), e.Key, e.Namespace().Name); err != nil {
+			errs = appendError(errs, err)
 		}
 		//fmt.Printf("recursive: path: %s, entryName: %s\n", newdynPath, e.Dir[k].Name)
 	}
-	return nil
+	return errs.ErrorOrNil()
+}
+
+// HeInfo holds the key information of a hierarchical (parent) resource
+type HeInfo struct {
+	Name string `json:"name,omitempty"`
+	Key  string `json:"key,omitempty"`
+	Type string `json:"type,omitempty"`
+}
+
+// getHierarchicalElements returns the key information of the parent resources
+// of the resource, the root resource is not part of the hierarchy
+func getHierarchicalElements(r *resource.Resource) []*HeInfo {
+	he := make([]*HeInfo, 0)
+	for p := r.GetParent(); p != nil && p.GetParent() != nil; p = p.GetParent() {
+		if e := p.GetRootContainerEntry(); e != nil {
+			he = append(he, &HeInfo{
+				Name: e.GetName(),
+				Key:  e.Key,
+				Type: e.GetType(),
+			})
+		}
+	}
+	return he
 }
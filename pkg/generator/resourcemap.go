@@ -0,0 +1,199 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	errUnknownField    = "unknown field"
+	errUnexpectedValue = "unexpected value"
+	errDuplicateKey    = "duplicate key"
+
+	resourceMapSchemaID = "https://github.com/yndd/ndd-ygen/resource-map.schema.json"
+)
+
+// DecodeResourceYamlInput strictly decodes a resource map. Unknown fields and values of
+// the wrong kind are reported with their line and column.
+func DecodeResourceYamlInput(b []byte) (*ResourceYamlInput, error) {
+	c := &ResourceYamlInput{}
+	n := &yaml.Node{}
+	if err := yaml.Unmarshal(b, n); err != nil {
+		return nil, err
+	}
+	// an empty document results in an empty resource map
+	if len(n.Content) == 0 {
+		return c, nil
+	}
+	if err := validateYamlNode(n.Content[0], reflect.TypeOf(c).Elem()).ErrorOrNil(); err != nil {
+		return nil, err
+	}
+	if err := n.Content[0].Decode(c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// validateYamlNode validates the yaml node against the go type it is decoded into
+func validateYamlNode(n *yaml.Node, t reflect.Type) Errors {
+	var errs Errors
+	// null values decode into the zero value of any type
+	if n.Kind == yaml.ScalarNode && n.Tag == "!!null" {
+		return errs
+	}
+	if n.Kind == yaml.AliasNode {
+		return validateYamlNode(n.Alias, t)
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		if n.Kind != yaml.MappingNode {
+			return appendError(errs, yamlNodeError(n, errUnexpectedValue, "expected a mapping"))
+		}
+		fields := map[string]reflect.StructField{}
+		names := make([]string, 0, t.NumField())
+		for i := 0; i < t.NumField(); i++ {
+			name := getYamlFieldName(t.Field(i))
+			fields[name] = t.Field(i)
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		seen := map[string]bool{}
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			k, v := n.Content[i], n.Content[i+1]
+			f, ok := fields[k.Value]
+			switch {
+			case !ok:
+				errs = appendError(errs, yamlNodeError(k, errUnknownField, "%q in %s, expected one of: %s", k.Value, t.Name(), strings.Join(names, ", ")))
+			case seen[k.Value]:
+				errs = appendError(errs, yamlNodeError(k, errDuplicateKey, "%q", k.Value))
+			default:
+				errs = append(errs, validateYamlNode(v, f.Type)...)
+			}
+			seen[k.Value] = true
+		}
+	case reflect.Map:
+		if n.Kind != yaml.MappingNode {
+			return appendError(errs, yamlNodeError(n, errUnexpectedValue, "expected a mapping"))
+		}
+		seen := map[string]bool{}
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			k, v := n.Content[i], n.Content[i+1]
+			if seen[k.Value] {
+				errs = appendError(errs, yamlNodeError(k, errDuplicateKey, "%q", k.Value))
+			}
+			seen[k.Value] = true
+			errs = append(errs, validateYamlNode(v, t.Elem())...)
+		}
+	case reflect.Slice:
+		if n.Kind != yaml.SequenceNode {
+			return appendError(errs, yamlNodeError(n, errUnexpectedValue, "expected a sequence"))
+		}
+		for _, v := range n.Content {
+			errs = append(errs, validateYamlNode(v, t.Elem())...)
+		}
+	default:
+		if n.Kind != yaml.ScalarNode {
+			return appendError(errs, yamlNodeError(n, errUnexpectedValue, "expected a %s", t.Kind()))
+		}
+	}
+	return errs
+}
+
+func yamlNodeError(n *yaml.Node, reason, format string, args ...interface{}) error {
+	return errors.Errorf("line %d, column %d: %s: %s", n.Line, n.Column, reason, errors.Errorf(format, args...))
+}
+
+func getYamlFieldName(f reflect.StructField) string {
+	name := strings.Split(f.Tag.Get("yaml"), ",")[0]
+	if name == "" {
+		return strings.ToLower(f.Name)
+	}
+	return name
+}
+
+// ResourceMapJSONSchema returns the JSON schema of the resource map format, derived
+// from the ResourceYamlInput struct such that it follows the decoder
+func ResourceMapJSONSchema() ([]byte, error) {
+	definitions := map[string]interface{}{}
+	schema := jsonSchemaOf(reflect.TypeOf(ResourceYamlInput{}), definitions)
+	schema["$schema"] = "http://json-schema.org/draft-07/schema#"
+	schema["$id"] = resourceMapSchemaID
+	schema["title"] = "ndd-ygen resource map"
+	schema["definitions"] = definitions
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// jsonSchemaOf returns the JSON schema of the go type, structs other than the root
+// are added to the definitions and referenced, which allows recursive structs
+func jsonSchemaOf(t reflect.Type, definitions map[string]interface{}) map[string]interface{} {
+	switch t.Kind() {
+	case reflect.Struct:
+		properties := map[string]interface{}{}
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			p := jsonSchemaRef(f.Type, definitions)
+			if d := f.Tag.Get("description"); d != "" {
+				p["description"] = d
+			}
+			properties[getYamlFieldName(f)] = p
+		}
+		return map[string]interface{}{
+			"type":                 "object",
+			"properties":           properties,
+			"additionalProperties": false,
+		}
+	case reflect.Map:
+		return map[string]interface{}{
+			"type":                 []string{"object", "null"},
+			"additionalProperties": jsonSchemaRef(t.Elem(), definitions),
+		}
+	case reflect.Slice:
+		return map[string]interface{}{
+			"type":  []string{"array", "null"},
+			"items": jsonSchemaRef(t.Elem(), definitions),
+		}
+	case reflect.Bool:
+		return map[string]interface{}{"type": []string{"boolean", "null"}}
+	default:
+		return map[string]interface{}{"type": []string{"string", "null"}}
+	}
+}
+
+func jsonSchemaRef(t reflect.Type, definitions map[string]interface{}) map[string]interface{} {
+	if t.Kind() != reflect.Struct {
+		return jsonSchemaOf(t, definitions)
+	}
+	if _, ok := definitions[t.Name()]; !ok {
+		// reserve the definition before recursing to support recursive structs
+		definitions[t.Name()] = nil
+		definitions[t.Name()] = jsonSchemaOf(t, definitions)
+	}
+	// a struct value may be null in the resource map, e.g. a hierarchy entry without details
+	return map[string]interface{}{
+		"anyOf": []interface{}{
+			map[string]interface{}{"$ref": "#/definitions/" + t.Name()},
+			map[string]interface{}{"type": "null"},
+		},
+	}
+}
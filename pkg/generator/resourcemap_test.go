@@ -0,0 +1,198 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDecodeResourceYamlInput(t *testing.T) {
+	cases := map[string]struct {
+		input   string
+		want    *ResourceYamlInput
+		wantErr string
+	}{
+		"Empty": {
+			input: "",
+			want:  &ResourceYamlInput{},
+		},
+		"Valid": {
+			input: `schema: /test
+path:
+  /test-system/system/server:
+    excludes:
+      - /timers
+    hierarchy:
+      /timers:
+static-leafref:
+  /a/b: /c/d
+`,
+			want: &ResourceYamlInput{
+				Schema: "/test",
+				Path: map[string]PathDetails{
+					"/test-system/system/server": {
+						Excludes:  []string{"/timers"},
+						Hierarchy: map[string]PathDetails{"/timers": {}},
+					},
+				},
+				StaticLeafref: map[string]string{"/a/b": "/c/d"},
+			},
+		},
+		"Alias": {
+			input: `path:
+  /a: &details
+    excludes:
+      - /x
+  /b: *details
+`,
+			want: &ResourceYamlInput{
+				Path: map[string]PathDetails{
+					"/a": {Excludes: []string{"/x"}},
+					"/b": {Excludes: []string{"/x"}},
+				},
+			},
+		},
+		"UnknownKey": {
+			input:   "paths:\n  /a:\n",
+			wantErr: `line 1, column 1: unknown field: "paths" in ResourceYamlInput, expected one of: path, schema, static-leafref`,
+		},
+		"UnknownNestedKey": {
+			input:   "path:\n  /a:\n    exclude:\n      - /x\n",
+			wantErr: `line 3, column 5: unknown field: "exclude" in PathDetails, expected one of: excludes, hierarchy`,
+		},
+		"UnknownHierarchyKey": {
+			input:   "path:\n  /a:\n    hierarchy:\n      /b:\n        hierarchies:\n",
+			wantErr: `line 5, column 9: unknown field: "hierarchies" in PathDetails, expected one of: excludes, hierarchy`,
+		},
+		"WrongTypeSequence": {
+			input:   "path:\n  /a:\n    excludes: /x\n",
+			wantErr: "line 3, column 15: unexpected value: expected a sequence",
+		},
+		"WrongTypeMapping": {
+			input:   "path:\n  - /a\n",
+			wantErr: "line 2, column 3: unexpected value: expected a mapping",
+		},
+		"WrongTypeScalar": {
+			input:   "schema:\n  - /a\n",
+			wantErr: "line 2, column 3: unexpected value: expected a string",
+		},
+		"DuplicatePath": {
+			input:   "path:\n  /a:\n  /b:\n  /a:\n",
+			wantErr: `line 4, column 3: duplicate key: "/a"`,
+		},
+		"DuplicateField": {
+			input:   "path:\n  /a:\npath:\n  /b:\n",
+			wantErr: `line 3, column 1: duplicate key: "path"`,
+		},
+		"MultipleErrors": {
+			input:   "path:\n  /a:\n    exclude:\n  /b:\n    excludes: /x\n",
+			wantErr: "2 errors occurred:\n\t* line 3, column 5: unknown field: \"exclude\" in PathDetails, expected one of: excludes, hierarchy\n\t* line 5, column 15: unexpected value: expected a sequence",
+		},
+		"Malformed": {
+			input:   "path:\n  /a: [\n",
+			wantErr: "yaml: line 2: did not find expected node content",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := DecodeResourceYamlInput([]byte(tc.input))
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Errorf("DecodeResourceYamlInput: got error %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("DecodeResourceYamlInput: got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestResourceMapJSONSchema(t *testing.T) {
+	b, err := ResourceMapJSONSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var schema map[string]interface{}
+	if err := json.Unmarshal(b, &schema); err != nil {
+		t.Fatal(err)
+	}
+	pathDetailsRef := map[string]interface{}{
+		"anyOf": []interface{}{
+			map[string]interface{}{"$ref": "#/definitions/PathDetails"},
+			map[string]interface{}{"type": "null"},
+		},
+	}
+	stringType := map[string]interface{}{"type": []interface{}{"string", "null"}}
+
+	cases := map[string]struct {
+		got  interface{}
+		want interface{}
+	}{
+		"ID": {
+			got:  schema["$id"],
+			want: resourceMapSchemaID,
+		},
+		"AdditionalProperties": {
+			got:  schema["additionalProperties"],
+			want: false,
+		},
+		"Path": {
+			got:  getJSONSchemaProperty(schema, "path")["additionalProperties"],
+			want: pathDetailsRef,
+		},
+		"StaticLeafref": {
+			got:  getJSONSchemaProperty(schema, "static-leafref")["additionalProperties"],
+			want: stringType,
+		},
+		"Excludes": {
+			got:  getJSONSchemaProperty(getJSONSchemaDefinition(schema, "PathDetails"), "excludes")["items"],
+			want: stringType,
+		},
+		"RecursiveHierarchy": {
+			got:  getJSONSchemaProperty(getJSONSchemaDefinition(schema, "PathDetails"), "hierarchy")["additionalProperties"],
+			want: pathDetailsRef,
+		},
+		"Description": {
+			got:  getJSONSchemaProperty(schema, "path")["description"],
+			want: "the resources to generate, keyed by the absolute path of the resource",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if !reflect.DeepEqual(tc.got, tc.want) {
+				t.Errorf("got %v, want %v", tc.got, tc.want)
+			}
+		})
+	}
+}
+
+func getJSONSchemaDefinition(schema map[string]interface{}, name string) map[string]interface{} {
+	d, _ := schema["definitions"].(map[string]interface{})[name].(map[string]interface{})
+	return d
+}
+
+func getJSONSchemaProperty(schema map[string]interface{}, name string) map[string]interface{} {
+	p, _ := schema["properties"].(map[string]interface{})[name].(map[string]interface{})
+	return p
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/pkg/errors"
+)
+
+const (
+	errInvalidModuleRevision  = "invalid module revision, expected module@revision"
+	errModuleRevisionNotFound = "cannot find the pinned revision of module"
+	errModuleRevisionConflict = "multiple revisions of module"
+)
+
+// parseModuleRevisions parses the pinned revisions specified as module@revision
+func parseModuleRevisions(pins []string) (map[string]string, error) {
+	revisions := make(map[string]string)
+	for _, pin := range pins {
+		split := strings.Split(pin, "@")
+		if len(split) != 2 || split[0] == "" || split[1] == "" {
+			return nil, errors.Errorf("%s: %s", errInvalidModuleRevision, pin)
+		}
+		if rev, ok := revisions[split[0]]; ok && rev != split[1] {
+			return nil, errors.Errorf("%s: %s, module is pinned to %s", errInvalidModuleRevision, pin, rev)
+		}
+		revisions[split[0]] = split[1]
+	}
+	return revisions, nil
+}
+
+// resolveModuleRevisions ensures that a single revision of every top level module is
+// processed and returns the sorted names of the top level modules. The top level modules
+// are the revisions read from the module dirs. When multiple revisions of a module are
+// read, the pinned revision is kept and the other revisions are removed from the module
+// set. Without a pin the revision of the module dirs is selected, multiple revisions of a
+// top level module in the module dirs are reported as an error, since the generated code
+// would depend on the revision that is picked. Multiple revisions of import modules are
+// allowed since modules can import a specific revision, goyang uses the latest revision
+// for imports without a revision-date.
+func (g *Generator) resolveModuleRevisions(moduleSet *yang.Modules, topModules map[string]bool) ([]string, error) {
+	pins, err := parseModuleRevisions(g.GetConfig().GetModuleRevisions())
+	if err != nil {
+		return nil, err
+	}
+
+	topNames := map[string]bool{}
+	for _, m := range moduleSet.Modules {
+		if topModules[m.FullName()] {
+			topNames[m.Name] = true
+		}
+	}
+
+	var errs Errors
+	for i, mods := range []map[string]*yang.Module{moduleSet.Modules, moduleSet.SubModules} {
+		// goyang registers a module by its full name and by its name for the latest revision
+		revisions := make(map[string][]*yang.Module)
+		for fullName, m := range mods {
+			if fullName == m.FullName() {
+				revisions[m.Name] = append(revisions[m.Name], m)
+			}
+		}
+		names := make([]string, 0, len(revisions))
+		for name := range revisions {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			ms := revisions[name]
+			sort.Slice(ms, func(i, j int) bool {
+				return ms[i].Current() < ms[j].Current()
+			})
+
+			if rev, ok := pins[name]; ok {
+				delete(pins, name)
+				var pinned *yang.Module
+				for _, m := range ms {
+					if m.Current() == rev {
+						pinned = m
+					}
+				}
+				if pinned == nil {
+					errs = appendError(errs, errors.Errorf("%s %s@%s, found %s", errModuleRevisionNotFound, name, rev, getModuleLocations(ms)))
+					continue
+				}
+				for _, m := range ms {
+					if m != pinned {
+						g.log.Debug("ignore module revision", "module", m.FullName(), "pinned", pinned.FullName())
+						delete(mods, m.FullName())
+					}
+				}
+				mods[name] = pinned
+				continue
+			}
+
+			if len(ms) == 1 {
+				continue
+			}
+			if i == 0 && topNames[name] {
+				selected := make([]*yang.Module, 0, 1)
+				for _, m := range ms {
+					if topModules[m.FullName()] {
+						selected = append(selected, m)
+					}
+				}
+				if len(selected) != 1 {
+					errs = appendError(errs, errors.Errorf("%s %s, pin one of the revisions: %s", errModuleRevisionConflict, name, getModuleLocations(ms)))
+					continue
+				}
+				// the other revisions are read from the import dirs and remain available
+				// to the modules that import them with a revision-date
+				g.log.Info("multiple revisions of module, the revision of the module dirs is processed",
+					"module", name, "selected", selected[0].FullName(), "revisions", getModuleLocations(ms))
+				mods[name] = selected[0]
+				continue
+			}
+			g.log.Info("multiple revisions of module, imports without revision-date use the latest revision",
+				"module", name, "revisions", getModuleLocations(ms))
+		}
+	}
+
+	// the pinned modules that are not read yet are imports that goyang resolves from
+	// the import dirs, the pinned revision is read upfront such that goyang uses it
+	names := make([]string, 0, len(pins))
+	for name := range pins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fullName := name + "@" + pins[name]
+		if err := moduleSet.Read(fullName); err != nil || moduleSet.Modules[fullName] == nil {
+			errs = appendError(errs, errors.Errorf("%s %s, module is not found", errModuleRevisionNotFound, fullName))
+			continue
+		}
+		moduleSet.Modules[name] = moduleSet.Modules[fullName]
+	}
+	if err := errs.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
+	names = make([]string, 0, len(topNames))
+	for name := range topNames {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// getModuleLocations returns the revisions of the module with the location they are read from
+func getModuleLocations(ms []*yang.Module) string {
+	locations := make([]string, 0, len(ms))
+	for _, m := range ms {
+		locations = append(locations, m.FullName()+" ("+yang.Source(m)+")")
+	}
+	return strings.Join(locations, ", ")
+}
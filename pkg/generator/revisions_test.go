@@ -0,0 +1,179 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yndd/ndd-runtime/pkg/logging"
+	"github.com/yndd/ndd-ygen/pkg/fsys"
+)
+
+func TestModuleRevisions(t *testing.T) {
+	inputFS := fsys.NewMemFS()
+	for name, data := range map[string]string{
+		"yang/test-interfaces@2021-01-01.yang": strings.Replace(testInterfacesYang, "prefix tif;", "prefix tif;\n  revision 2021-01-01;", 1),
+		"old/test-interfaces@2020-01-01.yang": strings.Replace(
+			strings.Replace(testInterfacesYang, "prefix tif;", "prefix tif;\n  revision 2020-01-01;", 1),
+			"leaf mtu", "leaf old-mtu", 1),
+		"import/test-types.yang": testTypesYang,
+		"map.yaml":               "path:\n  /test-interfaces/interfaces/interface:\n",
+	} {
+		if err := inputFS.WriteFile(name, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cases := map[string]struct {
+		revisions []string
+		wantErr   string
+		wantField string
+	}{
+		"Conflict": {
+			wantErr: "multiple revisions of module test-interfaces",
+		},
+		"PinLatest": {
+			revisions: []string{"test-interfaces@2021-01-01"},
+			wantField: "Mtu  *uint16",
+		},
+		"PinOld": {
+			revisions: []string{"test-interfaces@2020-01-01"},
+			wantField: "OldMtu *uint16",
+		},
+		"PinNotFound": {
+			revisions: []string{"test-interfaces@2019-01-01"},
+			wantErr:   "cannot find the pinned revision of module test-interfaces@2019-01-01",
+		},
+		"InvalidPin": {
+			revisions: []string{"test-interfaces"},
+			wantErr:   errInvalidModuleRevision,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			outputFS := fsys.NewMemFS()
+			g, err := NewGenerator(
+				WithInputFS(inputFS),
+				WithOutputFS(outputFS),
+				WithLogging(logging.NewNopLogger()),
+				WithYangImportDirs([]string{"import"}),
+				WithYangModuleDirs([]string{"yang", "old"}),
+				WithModuleRevisions(tc.revisions),
+				WithResourceMapInputFile("map.yaml"),
+				WithOutputDir("out"),
+				WithVersion("v1alpha1"),
+				WithAPIGroup("test.ndd.yndd.io"),
+				WithPrefix("test"),
+				WithLocalRender(true),
+			)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := g.Run(); err != nil {
+				t.Fatal(err)
+			}
+			if err := g.Render(); err != nil {
+				t.Fatal(err)
+			}
+			b, err := outputFS.ReadFile("out/apis/v1alpha1/test_interfaces_interface_types.go")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(b), tc.wantField) {
+				t.Errorf("expected %q in the rendered resource:\n%s", tc.wantField, b)
+			}
+		})
+	}
+}
+
+func TestModuleRevisionInImportDir(t *testing.T) {
+	inputFS := fsys.NewMemFS()
+	for name, data := range map[string]string{
+		"yang/test-interfaces@2021-01-01.yang": strings.Replace(testInterfacesYang, "prefix tif;", "prefix tif;\n  revision 2021-01-01;", 1),
+		"import/test-interfaces@2020-01-01.yang": strings.Replace(
+			strings.Replace(testInterfacesYang, "prefix tif;", "prefix tif;\n  revision 2020-01-01;", 1),
+			"leaf mtu", "leaf old-mtu", 1),
+		"import/test-types.yang": testTypesYang,
+		"map.yaml":               "path:\n  /test-interfaces/interfaces/interface:\n",
+	} {
+		if err := inputFS.WriteFile(name, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// another revision of a top level module in the import dirs does not drop the module
+	cases := map[string]struct {
+		revisions []string
+		wantField string
+	}{
+		"ModuleDirRevision": {
+			wantField: "Mtu  *uint16",
+		},
+		"PinModuleDirRevision": {
+			revisions: []string{"test-interfaces@2021-01-01"},
+			wantField: "Mtu  *uint16",
+		},
+		"PinImportDirRevision": {
+			revisions: []string{"test-interfaces@2020-01-01"},
+			wantField: "OldMtu *uint16",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			outputFS := fsys.NewMemFS()
+			g, err := NewGenerator(
+				WithInputFS(inputFS),
+				WithOutputFS(outputFS),
+				WithLogging(logging.NewNopLogger()),
+				WithYangImportDirs([]string{"import"}),
+				WithYangModuleDirs([]string{"yang"}),
+				WithModuleRevisions(tc.revisions),
+				WithResourceMapInputFile("map.yaml"),
+				WithOutputDir("out"),
+				WithVersion("v1alpha1"),
+				WithAPIGroup("test.ndd.yndd.io"),
+				WithPrefix("test"),
+				WithLocalRender(true),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := g.Run(); err != nil {
+				t.Fatal(err)
+			}
+			if err := g.Render(); err != nil {
+				t.Fatal(err)
+			}
+			b, err := outputFS.ReadFile("out/apis/v1alpha1/test_interfaces_interface_types.go")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(b), tc.wantField) {
+				t.Errorf("expected %q in the rendered resource:\n%s", tc.wantField, b)
+			}
+		})
+	}
+}
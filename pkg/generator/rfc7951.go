@@ -0,0 +1,277 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"bytes"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/pkg/errors"
+	"github.com/stoewer/go-strcase"
+	"github.com/yndd/ndd-yang/pkg/container"
+	"github.com/yndd/ndd-yang/pkg/yparser"
+)
+
+const (
+	// rfc7951FileName is the name of the file with the RFC 7951 conversion functions
+	rfc7951FileName = "zz_generated.rfc7951.go"
+)
+
+// the kinds of the members of a RFC 7951 JSON object
+const (
+	rfc7951KindList      = "list"       // a keyed list, encoded as an array of objects
+	rfc7951KindListEntry = "list-entry" // a list without key, the entry is encoded as an array with one object
+	rfc7951KindContainer = "container"  // a container, encoded as an object
+	rfc7951KindLeafList  = "leaf-list"  // a leaf-list, encoded as an array of values
+	rfc7951KindLeaf      = "leaf"       // a leaf, encoded as a value
+)
+
+// the encodings of the values of the leaves and leaf-lists
+const (
+	rfc7951EncodingString      = "string"
+	rfc7951EncodingBool        = "bool"
+	rfc7951EncodingInt         = "int"
+	rfc7951EncodingUint        = "uint"
+	rfc7951EncodingEmpty       = "empty"       // encoded as [null]
+	rfc7951EncodingIdentityRef = "identityref" // encoded with the module of the identity as prefix
+)
+
+// RFC7951Container is a struct of a resource that is converted to a RFC 7951 JSON object
+type RFC7951Container struct {
+	Name    string
+	Members []*RFC7951Member
+}
+
+// RFC7951Member is a member of the RFC 7951 JSON object of a container
+type RFC7951Member struct {
+	// Name is the name of the member, qualified with the module when the namespace of
+	// the member differs from the namespace of the container
+	Name       string
+	Field      string // the field of the go struct
+	Kind       string
+	Type       string // the go type of the field
+	ValueType  string // the go type of the value of a leaf or leaf-list
+	Encoding   string
+	Bits       int  // the bits of an integer value
+	Quoted     bool // 64 bit integers are encoded as a string
+	Identities []*RFC7951Identity
+}
+
+// RFC7951Identity is an identity of an identityref with the module of the identity
+type RFC7951Identity struct {
+	Name   string
+	Module string
+	// Qualified is set when modules define identities with the same name, the value of the
+	// field keeps the module qualifier of the identity to tell them apart
+	Qualified bool
+}
+
+// RFC7951Resource is a resource with the RFC 7951 conversion of the parameters
+type RFC7951Resource struct {
+	ResourceNameWithPrefix string
+	RootStruct             string
+}
+
+// setEntryYangType records the yang type of a leaf on the container entry, the container
+// entry only holds the go type of the leaf
+func (g *Generator) setEntryYangType(ce *container.Entry, e *yang.Entry) {
+	if e.Type != nil {
+		g.entryYangTypes[ce] = e.Type
+	}
+}
+
+// RenderRFC7951 writes the functions that convert the parameters of the custom resources
+// to the RFC 7951 JSON encoding of the resources and back to <outputDir>/apis/<version>/zz_generated.rfc7951.go
+func (g *Generator) RenderRFC7951() error {
+	g.startRender(renderKindRFC7951)
+	if err := g.checkTypeNames(); err != nil {
+		return err
+	}
+	s := struct {
+		Version    string
+		Containers []*RFC7951Container
+		Resources  []*RFC7951Resource
+	}{
+		Version:    g.GetConfig().GetVersion(),
+		Containers: make([]*RFC7951Container, 0),
+		Resources:  make([]*RFC7951Resource, 0),
+	}
+	for _, r := range g.getRenderResources() {
+		if r.RootContainer == nil {
+			return errors.Errorf("%s: %s", errResourceNotFound, yparser.GnmiPath2XPath(r.GetAbsolutePath(), false))
+		}
+		s.Containers = append(s.Containers, g.getRFC7951Containers(r.RootContainer, r.RootContainer.GetNamespace())...)
+		s.Resources = append(s.Resources, &RFC7951Resource{
+			ResourceNameWithPrefix: r.GetResourceNameWithPrefix(g.GetConfig().GetPrefix()),
+			RootStruct:             strcase.UpperCamelCase(r.RootContainer.GetFullName()),
+		})
+	}
+
+	buf := new(bytes.Buffer)
+	if err := g.getTemplate().ExecuteTemplate(buf, "rfc7951"+".tmpl", s); err != nil {
+		return err
+	}
+	b, err := formatSource(buf.Bytes())
+	if err != nil {
+		return errors.Wrapf(err, "%s, template rfc7951.tmpl", errFormatSource)
+	}
+	dir := filepath.Join(g.GetConfig().GetOutputDir(), "apis", g.GetConfig().GetVersion())
+	return g.writeFile(renderKindRFC7951, filepath.Join(dir, rfc7951FileName), b)
+}
+
+// getRFC7951Containers returns the container and the containers below it, the namespace
+// is the namespace of the container
+func (g *Generator) getRFC7951Containers(c *container.Container, namespace string) []*RFC7951Container {
+	rc := &RFC7951Container{Name: strcase.UpperCamelCase(c.GetFullName())}
+	containers := []*RFC7951Container{rc}
+	for _, e := range c.GetEntries() {
+		m := &RFC7951Member{
+			Name:  g.getRFC7951MemberName(e.GetName(), e.GetNamespace(), namespace),
+			Field: strcase.UpperCamelCase(e.GetName()),
+			Type:  e.GetType(),
+		}
+		switch leaf := g.GetEntryLeafList(e); {
+		case leaf != nil:
+			m.Kind = rfc7951KindLeafList
+			// the namespace of the leaf-list is recorded on the leaf
+			m.Name = g.getRFC7951MemberName(leaf.GetName(), leaf.GetNamespace(), namespace)
+			m.Type = "[]" + leaf.GetType()
+			g.setRFC7951Encoding(m, leaf)
+		case e.Next != nil:
+			switch {
+			case e.Key != "":
+				m.Kind = rfc7951KindList
+			case e.ListAttr != nil:
+				m.Kind = rfc7951KindListEntry
+			default:
+				m.Kind = rfc7951KindContainer
+			}
+			ns := e.GetNamespace()
+			if ns == "" {
+				ns = namespace
+			}
+			containers = append(containers, g.getRFC7951Containers(e.Next, ns)...)
+		default:
+			m.Kind = rfc7951KindLeaf
+			g.setRFC7951Encoding(m, e)
+		}
+		rc.Members = append(rc.Members, m)
+	}
+	return containers
+}
+
+// getRFC7951MemberName returns the name of the member qualified with the name of the module
+// when the namespace of the member differs from the namespace of the parent
+func (g *Generator) getRFC7951MemberName(name, namespace, parentNamespace string) string {
+	if namespace == "" || namespace == parentNamespace {
+		return name
+	}
+	if module := g.GetModuleName(namespace); module != "" {
+		return module + ":" + name
+	}
+	return name
+}
+
+// rfc7951IntegerBits holds the bits of the go integer types of the leaves
+var rfc7951IntegerBits = map[string]int{
+	"int8": 8, "int16": 16, "int32": 32, "int64": 64,
+	"uint8": 8, "uint16": 16, "uint32": 32, "uint64": 64,
+}
+
+// setRFC7951Encoding sets the encoding of the value of the leaf on the member
+func (g *Generator) setRFC7951Encoding(m *RFC7951Member, e *container.Entry) {
+	m.ValueType = e.GetType()
+	switch bits, ok := rfc7951IntegerBits[m.ValueType]; {
+	case ok:
+		m.Encoding = rfc7951EncodingInt
+		if strings.HasPrefix(m.ValueType, "u") {
+			m.Encoding = rfc7951EncodingUint
+		}
+		m.Bits = bits
+		m.Quoted = bits == 64
+		return
+	case m.ValueType == "bool":
+		m.Encoding = rfc7951EncodingBool
+		return
+	}
+	m.Encoding = rfc7951EncodingString
+	t, ok := g.entryYangTypes[e]
+	if !ok {
+		return
+	}
+	if t.Kind == yang.Yempty {
+		m.Encoding = rfc7951EncodingEmpty
+		return
+	}
+	if identities := getIdentities(t); len(identities) > 0 {
+		m.Encoding = rfc7951EncodingIdentityRef
+		m.Identities = identities
+	}
+}
+
+// getIdentities returns the identities of an identityref or of the identityrefs of a union,
+// sorted by name and module. The identities are identified by module and name, the
+// identities with a name that is defined by multiple modules are qualified.
+func getIdentities(t *yang.YangType) []*RFC7951Identity {
+	identities := map[string]*RFC7951Identity{}
+	modules := map[string]int{}
+	var walk func(t *yang.YangType)
+	walk = func(t *yang.YangType) {
+		if t.Kind == yang.Yidentityref && t.IdentityBase != nil {
+			for _, i := range t.IdentityBase.Values {
+				module := getIdentityModule(i)
+				if _, ok := identities[module+":"+i.Name]; !ok {
+					identities[module+":"+i.Name] = &RFC7951Identity{Name: i.Name, Module: module}
+					modules[i.Name]++
+				}
+			}
+		}
+		for _, ut := range t.Type {
+			walk(ut)
+		}
+	}
+	walk(t)
+
+	result := make([]*RFC7951Identity, 0, len(identities))
+	for _, i := range identities {
+		i.Qualified = modules[i.Name] > 1
+		result = append(result, i)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
+		}
+		return result[i].Module < result[j].Module
+	})
+	return result
+}
+
+// getIdentityModule returns the name of the module that defines the identity, the module
+// the submodule belongs to when the identity is defined in a submodule
+func getIdentityModule(i *yang.Identity) string {
+	m := yang.RootNode(i)
+	if m == nil {
+		return ""
+	}
+	if m.BelongsTo != nil {
+		return m.BelongsTo.Name
+	}
+	return m.Name
+}
@@ -0,0 +1,268 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/yndd/ndd-runtime/pkg/logging"
+	"github.com/yndd/ndd-ygen/pkg/fsys"
+)
+
+const testSystemYang = `module test-system {
+  namespace "urn:test:system";
+  prefix ts;
+
+  identity protocol;
+
+  identity ssh {
+    base protocol;
+  }
+
+  container system {
+    list server {
+      key "name";
+      leaf name {
+        type string;
+      }
+      leaf protocol {
+        type identityref {
+          base protocol;
+        }
+      }
+      leaf counter {
+        type uint64;
+      }
+      leaf enabled {
+        type empty;
+      }
+    }
+  }
+}
+`
+
+const testSystemExtYang = `module test-system-ext {
+  namespace "urn:test:system-ext";
+  prefix tse;
+
+  import test-system {
+    prefix ts;
+  }
+
+  identity telnet {
+    base ts:protocol;
+  }
+
+  augment "/ts:system/ts:server" {
+    leaf-list tags {
+      type string;
+    }
+    container timers {
+      leaf idle {
+        type int32;
+      }
+    }
+  }
+}
+`
+
+// testSystemAltYang defines an identity with the same name as an identity of test-system
+const testSystemAltYang = `module test-system-alt {
+  namespace "urn:test:system-alt";
+  prefix tsa;
+
+  import test-system {
+    prefix ts;
+  }
+
+  identity ssh {
+    base ts:protocol;
+  }
+}
+`
+
+// newRFC7951TestGenerator returns a generator that processed the server resource of test-system
+func newRFC7951TestGenerator(t *testing.T, outputFS fsys.WriteFS) *Generator {
+	t.Helper()
+	inputFS := fsys.NewMemFS()
+	for name, data := range map[string]string{
+		"yang/test-system.yang":     testSystemYang,
+		"yang/test-system-ext.yang": testSystemExtYang,
+		"yang/test-system-alt.yang": testSystemAltYang,
+		"map.yaml":                  "path:\n  /test-system/system/server:\n",
+	} {
+		if err := inputFS.WriteFile(name, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	g, err := NewGenerator(
+		WithInputFS(inputFS),
+		WithOutputFS(outputFS),
+		WithLogging(logging.NewNopLogger()),
+		WithYangModuleDirs([]string{"yang"}),
+		WithResourceMapInputFile("map.yaml"),
+		WithOutputDir(testOutputDir),
+		WithVersion("v1alpha1"),
+		WithAPIGroup("test.ndd.yndd.io"),
+		WithPrefix("test"),
+		WithLocalRender(true),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Run(); err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestRFC7951Containers(t *testing.T) {
+	g := newRFC7951TestGenerator(t, fsys.NewMemFS())
+
+	r := g.getRenderResources()[0]
+	got := g.getRFC7951Containers(r.RootContainer, r.RootContainer.GetNamespace())
+	want := []*RFC7951Container{
+		{
+			Name: "Server",
+			Members: []*RFC7951Member{
+				{Name: "counter", Field: "Counter", Kind: rfc7951KindLeaf, Type: "uint64", ValueType: "uint64", Encoding: rfc7951EncodingUint, Bits: 64, Quoted: true},
+				{Name: "enabled", Field: "Enabled", Kind: rfc7951KindLeaf, Type: "string", ValueType: "string", Encoding: rfc7951EncodingEmpty},
+				{Name: "name", Field: "Name", Kind: rfc7951KindLeaf, Type: "string", ValueType: "string", Encoding: rfc7951EncodingString},
+				{Name: "protocol", Field: "Protocol", Kind: rfc7951KindLeaf, Type: "string", ValueType: "string", Encoding: rfc7951EncodingIdentityRef, Identities: []*RFC7951Identity{
+					{Name: "ssh", Module: "test-system", Qualified: true},
+					{Name: "ssh", Module: "test-system-alt", Qualified: true},
+					{Name: "telnet", Module: "test-system-ext"},
+				}},
+				{Name: "test-system-ext:tags", Field: "Tags", Kind: rfc7951KindLeafList, Type: "[]string", ValueType: "string", Encoding: rfc7951EncodingString},
+				{Name: "test-system-ext:timers", Field: "Timers", Kind: rfc7951KindContainer, Type: "ServerTimers"},
+			},
+		},
+		{
+			Name: "ServerTimers",
+			Members: []*RFC7951Member{
+				{Name: "idle", Field: "Idle", Kind: rfc7951KindLeaf, Type: "int32", ValueType: "int32", Encoding: rfc7951EncodingInt, Bits: 32},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		gb, _ := json.MarshalIndent(got, "", "  ")
+		wb, _ := json.MarshalIndent(want, "", "  ")
+		t.Errorf("got:\n%s\nwant:\n%s", gb, wb)
+	}
+}
+
+// testRFC7951RoundTrip decodes and encodes the RFC 7951 JSON of the server resource with
+// the rendered api package
+const testRFC7951RoundTrip = `package v1alpha1
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRFC7951RoundTrip(t *testing.T) {
+	cases := map[string]struct {
+		in       string
+		tags     []string
+		protocol string
+		wantErr  string
+	}{
+		"LeafList": {
+			in:   ` + "`" + `{"name": "s1", "test-system-ext:tags": ["a", "b", "c"]}` + "`" + `,
+			tags: []string{"a", "b", "c"},
+		},
+		"UnqualifiedIdentity": {
+			in:       ` + "`" + `{"name": "s1", "protocol": "test-system-ext:telnet"}` + "`" + `,
+			protocol: "telnet",
+		},
+		"QualifiedIdentity": {
+			in:       ` + "`" + `{"name": "s1", "protocol": "test-system-alt:ssh"}` + "`" + `,
+			protocol: "test-system-alt:ssh",
+		},
+		"AmbiguousMember": {
+			in:      ` + "`" + `{"name": "s1", "a:counter": "1", "b:counter": "2"}` + "`" + `,
+			wantErr: "counter: ambiguous member, matches a:counter, b:counter",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := &TestSystemServerParameters{}
+			err := p.UnmarshalRFC7951([]byte(tc.in))
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(p.TestSystemServer.Tags, tc.tags) {
+				t.Errorf("tags: got %v, want %v", p.TestSystemServer.Tags, tc.tags)
+			}
+			if tc.protocol != "" && (p.TestSystemServer.Protocol == nil || *p.TestSystemServer.Protocol != tc.protocol) {
+				t.Errorf("protocol: got %v, want %s", p.TestSystemServer.Protocol, tc.protocol)
+			}
+
+			b, err := p.MarshalRFC7951()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got, want interface{}
+			if err := json.Unmarshal(b, &got); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal([]byte(tc.in), &want); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("got %s, want %s", b, tc.in)
+			}
+		})
+	}
+}
+`
+
+func TestRFC7951RoundTrip(t *testing.T) {
+	outputFS := fsys.NewMemFS()
+	g := newRFC7951TestGenerator(t, outputFS)
+	for _, render := range []func() error{g.Render, g.RenderDeepCopy, g.RenderRFC7951} {
+		if err := render(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files := map[string][]byte{}
+	for _, name := range outputFS.GetFileNames() {
+		b, err := outputFS.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rel, err := filepath.Rel(testOutputDir, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[rel] = b
+	}
+	files[filepath.Join("apis", "v1alpha1", "rfc7951_test.go")] = []byte(testRFC7951RoundTrip)
+	runGoModule(t, files, []string{"test", "./apis/..."})
+}
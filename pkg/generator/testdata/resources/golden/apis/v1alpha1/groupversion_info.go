@@ -0,0 +1,77 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1alpha1 contains API Schema definitions for the sample.ndd.yndd.io v1alpha1 API group
+// +kubebuilder:object:generate=true
+// +groupName=sample.ndd.yndd.io
+package v1alpha1
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/scheme"
+)
+
+var (
+	// GroupVersion is group version used to register these objects
+	GroupVersion = schema.GroupVersion{Group: "sample.ndd.yndd.io", Version: "v1alpha1"}
+
+	// SchemeBuilder is used to add go types to the GroupVersionKind scheme
+	SchemeBuilder = &scheme.Builder{GroupVersion: GroupVersion}
+
+	// AddToScheme adds the types in this group-version to the given scheme.
+	AddToScheme = SchemeBuilder.AddToScheme
+)
+
+// SampleInterfacesInterface type metadata.
+var (
+	SampleInterfacesInterfaceKind             = reflect.TypeOf(SampleInterfacesInterface{}).Name()
+	SampleInterfacesInterfaceGroupKind        = schema.GroupKind{Group: GroupVersion.Group, Kind: SampleInterfacesInterfaceKind}.String()
+	SampleInterfacesInterfaceKindAPIVersion   = SampleInterfacesInterfaceKind + "." + GroupVersion.String()
+	SampleInterfacesInterfaceGroupVersionKind = GroupVersion.WithKind(SampleInterfacesInterfaceKind)
+)
+
+// SampleInterfacesInterfaceSubinterface type metadata.
+var (
+	SampleInterfacesInterfaceSubinterfaceKind             = reflect.TypeOf(SampleInterfacesInterfaceSubinterface{}).Name()
+	SampleInterfacesInterfaceSubinterfaceGroupKind        = schema.GroupKind{Group: GroupVersion.Group, Kind: SampleInterfacesInterfaceSubinterfaceKind}.String()
+	SampleInterfacesInterfaceSubinterfaceKindAPIVersion   = SampleInterfacesInterfaceSubinterfaceKind + "." + GroupVersion.String()
+	SampleInterfacesInterfaceSubinterfaceGroupVersionKind = GroupVersion.WithKind(SampleInterfacesInterfaceSubinterfaceKind)
+)
+
+// SampleNetworkinstancesNetworkinstance type metadata.
+var (
+	SampleNetworkinstancesNetworkinstanceKind             = reflect.TypeOf(SampleNetworkinstancesNetworkinstance{}).Name()
+	SampleNetworkinstancesNetworkinstanceGroupKind        = schema.GroupKind{Group: GroupVersion.Group, Kind: SampleNetworkinstancesNetworkinstanceKind}.String()
+	SampleNetworkinstancesNetworkinstanceKindAPIVersion   = SampleNetworkinstancesNetworkinstanceKind + "." + GroupVersion.String()
+	SampleNetworkinstancesNetworkinstanceGroupVersionKind = GroupVersion.WithKind(SampleNetworkinstancesNetworkinstanceKind)
+)
+
+// SampleNetworkinstancesNetworkinstanceProtocolsBgp type metadata.
+var (
+	SampleNetworkinstancesNetworkinstanceProtocolsBgpKind             = reflect.TypeOf(SampleNetworkinstancesNetworkinstanceProtocolsBgp{}).Name()
+	SampleNetworkinstancesNetworkinstanceProtocolsBgpGroupKind        = schema.GroupKind{Group: GroupVersion.Group, Kind: SampleNetworkinstancesNetworkinstanceProtocolsBgpKind}.String()
+	SampleNetworkinstancesNetworkinstanceProtocolsBgpKindAPIVersion   = SampleNetworkinstancesNetworkinstanceProtocolsBgpKind + "." + GroupVersion.String()
+	SampleNetworkinstancesNetworkinstanceProtocolsBgpGroupVersionKind = GroupVersion.WithKind(SampleNetworkinstancesNetworkinstanceProtocolsBgpKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&SampleInterfacesInterface{}, &SampleInterfacesInterfaceList{})
+	SchemeBuilder.Register(&SampleInterfacesInterfaceSubinterface{}, &SampleInterfacesInterfaceSubinterfaceList{})
+	SchemeBuilder.Register(&SampleNetworkinstancesNetworkinstance{}, &SampleNetworkinstancesNetworkinstanceList{})
+	SchemeBuilder.Register(&SampleNetworkinstancesNetworkinstanceProtocolsBgp{}, &SampleNetworkinstancesNetworkinstanceProtocolsBgpList{})
+}
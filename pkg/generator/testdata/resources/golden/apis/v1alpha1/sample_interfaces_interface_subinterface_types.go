@@ -0,0 +1,100 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	nddv1 "github.com/yndd/ndd-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// SampleInterfacesInterfaceSubinterfaceFinalizer is the name of the finalizer added to
+	// SampleInterfacesInterfaceSubinterface to block delete operations until the physical node can be
+	// deprovisioned.
+	SampleInterfacesInterfaceSubinterfaceFinalizer string = "subinterface.sample.ndd.yndd.io"
+)
+
+// Subinterface struct
+type Subinterface struct {
+	Description *string `json:"description,omitempty"`
+	// kubebuilder:validation:Minimum=0
+	// kubebuilder:validation:Maximum=4294967295
+	Index *uint32           `json:"index"`
+	Ipv4  *SubinterfaceIpv4 `json:"ipv4,omitempty"`
+}
+
+// SubinterfaceIpv4 struct
+type SubinterfaceIpv4 struct {
+	Address []*SubinterfaceIpv4Address `json:"address,omitempty"`
+}
+
+// SubinterfaceIpv4Address struct
+type SubinterfaceIpv4Address struct {
+	IpPrefix *string `json:"ip-prefix"`
+	Primary  *bool   `json:"primary,omitempty"`
+}
+
+// SampleInterfacesInterfaceSubinterfaceSpec struct
+type SampleInterfacesInterfaceSubinterfaceParameters struct {
+	SampleInterfaceName                   *string       `json:"interface-name"`
+	SampleInterfacesInterfaceSubinterface *Subinterface `json:"interfaces-interface-subinterface"`
+}
+
+// SampleInterfacesInterfaceSubinterfaceStatus struct
+type SampleInterfacesInterfaceSubinterfaceObservation struct {
+}
+
+// A SampleInterfacesInterfaceSubinterfaceSpec defines the desired state of a SampleInterfacesInterfaceSubinterface.
+type SampleInterfacesInterfaceSubinterfaceSpec struct {
+	nddv1.ResourceSpec `json:",inline"`
+	ForNetworkNode     SampleInterfacesInterfaceSubinterfaceParameters `json:"forNetworkNode"`
+}
+
+// A SampleInterfacesInterfaceSubinterfaceStatus represents the observed state of a SampleInterfacesInterfaceSubinterface.
+type SampleInterfacesInterfaceSubinterfaceStatus struct {
+	nddv1.ResourceStatus `json:",inline"`
+	AtNetworkNode        SampleInterfacesInterfaceSubinterfaceObservation `json:"atNetworkNode,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SampleInterfacesInterfaceSubinterface is the Schema for the SampleInterfacesInterfaceSubinterface API
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="TARGET",type="string",JSONPath=".status.conditions[?(@.kind=='TargetFound')].status"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.conditions[?(@.kind=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNC",type="string",JSONPath=".status.conditions[?(@.kind=='Synced')].status"
+// +kubebuilder:printcolumn:name="LOCALLEAFREF",type="string",JSONPath=".status.conditions[?(@.kind=='InternalLeafrefValidationSuccess')].status"
+// +kubebuilder:printcolumn:name="EXTLEAFREF",type="string",JSONPath=".status.conditions[?(@.kind=='ExternalLeafrefValidationSuccess')].status"
+// +kubebuilder:printcolumn:name="PARENTDEP",type="string",JSONPath=".status.conditions[?(@.kind=='ParentValidationSuccess')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={ndd,sample}
+type SampleInterfacesInterfaceSubinterface struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SampleInterfacesInterfaceSubinterfaceSpec   `json:"spec,omitempty"`
+	Status SampleInterfacesInterfaceSubinterfaceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SampleInterfacesInterfaceSubinterfaceList contains a list of SampleInterfacesInterfaceSubinterfaces
+type SampleInterfacesInterfaceSubinterfaceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SampleInterfacesInterfaceSubinterface `json:"items"`
+}
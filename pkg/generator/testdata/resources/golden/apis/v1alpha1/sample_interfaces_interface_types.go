@@ -0,0 +1,98 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	nddv1 "github.com/yndd/ndd-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// SampleInterfacesInterfaceFinalizer is the name of the finalizer added to
+	// SampleInterfacesInterface to block delete operations until the physical node can be
+	// deprovisioned.
+	SampleInterfacesInterfaceFinalizer string = "interface.sample.ndd.yndd.io"
+)
+
+// Interface struct
+type Interface struct {
+	// +kubebuilder:validation:Enum=`disable`;`enable`
+	// +kubebuilder:default:=enable
+	AdminState  *string `json:"admin-state,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Untagged    *string `json:"untagged,omitempty"`
+	// kubebuilder:validation:Minimum=1
+	// kubebuilder:validation:Maximum=4094
+	VlanId    *uint16 `json:"vlan-id,omitempty"`
+	EncapType *string `json:"encap-type,omitempty"`
+	// kubebuilder:validation:MinLength=1
+	// kubebuilder:validation:MaxLength=20
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`(ethernet-[0-9]+/[0-9]+|lo[0-9]+)`
+	Name *string  `json:"name"`
+	Tags []string `json:"tags,omitempty"`
+}
+
+// SampleInterfacesInterfaceSpec struct
+type SampleInterfacesInterfaceParameters struct {
+	SampleInterfacesInterface *Interface `json:"interfaces-interface"`
+}
+
+// SampleInterfacesInterfaceStatus struct
+type SampleInterfacesInterfaceObservation struct {
+}
+
+// A SampleInterfacesInterfaceSpec defines the desired state of a SampleInterfacesInterface.
+type SampleInterfacesInterfaceSpec struct {
+	nddv1.ResourceSpec `json:",inline"`
+	ForNetworkNode     SampleInterfacesInterfaceParameters `json:"forNetworkNode"`
+}
+
+// A SampleInterfacesInterfaceStatus represents the observed state of a SampleInterfacesInterface.
+type SampleInterfacesInterfaceStatus struct {
+	nddv1.ResourceStatus `json:",inline"`
+	AtNetworkNode        SampleInterfacesInterfaceObservation `json:"atNetworkNode,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SampleInterfacesInterface is the Schema for the SampleInterfacesInterface API
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="TARGET",type="string",JSONPath=".status.conditions[?(@.kind=='TargetFound')].status"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.conditions[?(@.kind=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNC",type="string",JSONPath=".status.conditions[?(@.kind=='Synced')].status"
+// +kubebuilder:printcolumn:name="LOCALLEAFREF",type="string",JSONPath=".status.conditions[?(@.kind=='InternalLeafrefValidationSuccess')].status"
+// +kubebuilder:printcolumn:name="EXTLEAFREF",type="string",JSONPath=".status.conditions[?(@.kind=='ExternalLeafrefValidationSuccess')].status"
+// +kubebuilder:printcolumn:name="PARENTDEP",type="string",JSONPath=".status.conditions[?(@.kind=='ParentValidationSuccess')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={ndd,sample}
+type SampleInterfacesInterface struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SampleInterfacesInterfaceSpec   `json:"spec,omitempty"`
+	Status SampleInterfacesInterfaceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SampleInterfacesInterfaceList contains a list of SampleInterfacesInterfaces
+type SampleInterfacesInterfaceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SampleInterfacesInterface `json:"items"`
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	nddv1 "github.com/yndd/ndd-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// SampleNetworkinstancesNetworkinstanceProtocolsBgpFinalizer is the name of the finalizer added to
+	// SampleNetworkinstancesNetworkinstanceProtocolsBgp to block delete operations until the physical node can be
+	// deprovisioned.
+	SampleNetworkinstancesNetworkinstanceProtocolsBgpFinalizer string = "bgp.sample.ndd.yndd.io"
+)
+
+// Bgp struct
+type Bgp struct {
+	// kubebuilder:validation:Minimum=0
+	// kubebuilder:validation:Maximum=4294967295
+	AutonomousSystem *uint32        `json:"autonomous-system"`
+	Neighbor         []*BgpNeighbor `json:"neighbor,omitempty"`
+}
+
+// BgpNeighbor struct
+type BgpNeighbor struct {
+	ExportPolicy []string `json:"export-policy,omitempty"`
+	PeerAddress  *string  `json:"peer-address"`
+	// kubebuilder:validation:Minimum=0
+	// kubebuilder:validation:Maximum=4294967295
+	PeerAs *uint32 `json:"peer-as,omitempty"`
+}
+
+// SampleNetworkinstancesNetworkinstanceProtocolsBgpSpec struct
+type SampleNetworkinstancesNetworkinstanceProtocolsBgpParameters struct {
+	SampleNetworkInstanceName                         *string `json:"network-instance-name"`
+	SampleNetworkinstancesNetworkinstanceProtocolsBgp *Bgp    `json:"networkinstances-networkinstance-protocols-bgp"`
+}
+
+// SampleNetworkinstancesNetworkinstanceProtocolsBgpStatus struct
+type SampleNetworkinstancesNetworkinstanceProtocolsBgpObservation struct {
+}
+
+// A SampleNetworkinstancesNetworkinstanceProtocolsBgpSpec defines the desired state of a SampleNetworkinstancesNetworkinstanceProtocolsBgp.
+type SampleNetworkinstancesNetworkinstanceProtocolsBgpSpec struct {
+	nddv1.ResourceSpec `json:",inline"`
+	ForNetworkNode     SampleNetworkinstancesNetworkinstanceProtocolsBgpParameters `json:"forNetworkNode"`
+}
+
+// A SampleNetworkinstancesNetworkinstanceProtocolsBgpStatus represents the observed state of a SampleNetworkinstancesNetworkinstanceProtocolsBgp.
+type SampleNetworkinstancesNetworkinstanceProtocolsBgpStatus struct {
+	nddv1.ResourceStatus `json:",inline"`
+	AtNetworkNode        SampleNetworkinstancesNetworkinstanceProtocolsBgpObservation `json:"atNetworkNode,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SampleNetworkinstancesNetworkinstanceProtocolsBgp is the Schema for the SampleNetworkinstancesNetworkinstanceProtocolsBgp API
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="TARGET",type="string",JSONPath=".status.conditions[?(@.kind=='TargetFound')].status"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.conditions[?(@.kind=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNC",type="string",JSONPath=".status.conditions[?(@.kind=='Synced')].status"
+// +kubebuilder:printcolumn:name="LOCALLEAFREF",type="string",JSONPath=".status.conditions[?(@.kind=='InternalLeafrefValidationSuccess')].status"
+// +kubebuilder:printcolumn:name="EXTLEAFREF",type="string",JSONPath=".status.conditions[?(@.kind=='ExternalLeafrefValidationSuccess')].status"
+// +kubebuilder:printcolumn:name="PARENTDEP",type="string",JSONPath=".status.conditions[?(@.kind=='ParentValidationSuccess')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={ndd,sample}
+type SampleNetworkinstancesNetworkinstanceProtocolsBgp struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SampleNetworkinstancesNetworkinstanceProtocolsBgpSpec   `json:"spec,omitempty"`
+	Status SampleNetworkinstancesNetworkinstanceProtocolsBgpStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SampleNetworkinstancesNetworkinstanceProtocolsBgpList contains a list of SampleNetworkinstancesNetworkinstanceProtocolsBgps
+type SampleNetworkinstancesNetworkinstanceProtocolsBgpList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SampleNetworkinstancesNetworkinstanceProtocolsBgp `json:"items"`
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	nddv1 "github.com/yndd/ndd-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// SampleNetworkinstancesNetworkinstanceFinalizer is the name of the finalizer added to
+	// SampleNetworkinstancesNetworkinstance to block delete operations until the physical node can be
+	// deprovisioned.
+	SampleNetworkinstancesNetworkinstanceFinalizer string = "networkInstance.sample.ndd.yndd.io"
+)
+
+// Networkinstance struct
+type Networkinstance struct {
+	DefaultInterface *string                     `json:"default-interface,omitempty"`
+	Interface        []*NetworkinstanceInterface `json:"interface,omitempty"`
+	Name             *string                     `json:"name"`
+	RouterId         *string                     `json:"router-id,omitempty"`
+	// +kubebuilder:validation:Enum=`default`;`ip-vrf`;`mac-vrf`
+	// +kubebuilder:default:=default
+	Type *string `json:"type,omitempty"`
+}
+
+// NetworkinstanceInterface struct
+type NetworkinstanceInterface struct {
+	Name         *string `json:"name"`
+	Subinterface *string `json:"subinterface"`
+}
+
+// SampleNetworkinstancesNetworkinstanceSpec struct
+type SampleNetworkinstancesNetworkinstanceParameters struct {
+	SampleNetworkinstancesNetworkinstance *Networkinstance `json:"networkinstances-networkinstance"`
+}
+
+// SampleNetworkinstancesNetworkinstanceStatus struct
+type SampleNetworkinstancesNetworkinstanceObservation struct {
+}
+
+// A SampleNetworkinstancesNetworkinstanceSpec defines the desired state of a SampleNetworkinstancesNetworkinstance.
+type SampleNetworkinstancesNetworkinstanceSpec struct {
+	nddv1.ResourceSpec `json:",inline"`
+	ForNetworkNode     SampleNetworkinstancesNetworkinstanceParameters `json:"forNetworkNode"`
+}
+
+// A SampleNetworkinstancesNetworkinstanceStatus represents the observed state of a SampleNetworkinstancesNetworkinstance.
+type SampleNetworkinstancesNetworkinstanceStatus struct {
+	nddv1.ResourceStatus `json:",inline"`
+	AtNetworkNode        SampleNetworkinstancesNetworkinstanceObservation `json:"atNetworkNode,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SampleNetworkinstancesNetworkinstance is the Schema for the SampleNetworkinstancesNetworkinstance API
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="TARGET",type="string",JSONPath=".status.conditions[?(@.kind=='TargetFound')].status"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.conditions[?(@.kind=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNC",type="string",JSONPath=".status.conditions[?(@.kind=='Synced')].status"
+// +kubebuilder:printcolumn:name="LOCALLEAFREF",type="string",JSONPath=".status.conditions[?(@.kind=='InternalLeafrefValidationSuccess')].status"
+// +kubebuilder:printcolumn:name="EXTLEAFREF",type="string",JSONPath=".status.conditions[?(@.kind=='ExternalLeafrefValidationSuccess')].status"
+// +kubebuilder:printcolumn:name="PARENTDEP",type="string",JSONPath=".status.conditions[?(@.kind=='ParentValidationSuccess')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={ndd,sample}
+type SampleNetworkinstancesNetworkinstance struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SampleNetworkinstancesNetworkinstanceSpec   `json:"spec,omitempty"`
+	Status SampleNetworkinstancesNetworkinstanceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SampleNetworkinstancesNetworkinstanceList contains a list of SampleNetworkinstancesNetworkinstances
+type SampleNetworkinstancesNetworkinstanceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SampleNetworkinstancesNetworkinstance `json:"items"`
+}
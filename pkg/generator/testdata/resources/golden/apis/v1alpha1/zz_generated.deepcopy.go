@@ -0,0 +1,811 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by ndd-ygen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Interface) DeepCopyInto(out *Interface) {
+	*out = *in
+	if in.AdminState != nil {
+		in, out := &in.AdminState, &out.AdminState
+		*out = new(string)
+		**out = **in
+	}
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+	if in.Untagged != nil {
+		in, out := &in.Untagged, &out.Untagged
+		*out = new(string)
+		**out = **in
+	}
+	if in.VlanId != nil {
+		in, out := &in.VlanId, &out.VlanId
+		*out = new(uint16)
+		**out = **in
+	}
+	if in.EncapType != nil {
+		in, out := &in.EncapType, &out.EncapType
+		*out = new(string)
+		**out = **in
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Interface.
+func (in *Interface) DeepCopy() *Interface {
+	if in == nil {
+		return nil
+	}
+	out := new(Interface)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Subinterface) DeepCopyInto(out *Subinterface) {
+	*out = *in
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+	if in.Index != nil {
+		in, out := &in.Index, &out.Index
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.Ipv4 != nil {
+		in, out := &in.Ipv4, &out.Ipv4
+		*out = new(SubinterfaceIpv4)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Subinterface.
+func (in *Subinterface) DeepCopy() *Subinterface {
+	if in == nil {
+		return nil
+	}
+	out := new(Subinterface)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubinterfaceIpv4) DeepCopyInto(out *SubinterfaceIpv4) {
+	*out = *in
+	if in.Address != nil {
+		in, out := &in.Address, &out.Address
+		*out = make([]*SubinterfaceIpv4Address, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(SubinterfaceIpv4Address)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubinterfaceIpv4.
+func (in *SubinterfaceIpv4) DeepCopy() *SubinterfaceIpv4 {
+	if in == nil {
+		return nil
+	}
+	out := new(SubinterfaceIpv4)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubinterfaceIpv4Address) DeepCopyInto(out *SubinterfaceIpv4Address) {
+	*out = *in
+	if in.IpPrefix != nil {
+		in, out := &in.IpPrefix, &out.IpPrefix
+		*out = new(string)
+		**out = **in
+	}
+	if in.Primary != nil {
+		in, out := &in.Primary, &out.Primary
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubinterfaceIpv4Address.
+func (in *SubinterfaceIpv4Address) DeepCopy() *SubinterfaceIpv4Address {
+	if in == nil {
+		return nil
+	}
+	out := new(SubinterfaceIpv4Address)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Networkinstance) DeepCopyInto(out *Networkinstance) {
+	*out = *in
+	if in.DefaultInterface != nil {
+		in, out := &in.DefaultInterface, &out.DefaultInterface
+		*out = new(string)
+		**out = **in
+	}
+	if in.Interface != nil {
+		in, out := &in.Interface, &out.Interface
+		*out = make([]*NetworkinstanceInterface, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(NetworkinstanceInterface)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.RouterId != nil {
+		in, out := &in.RouterId, &out.RouterId
+		*out = new(string)
+		**out = **in
+	}
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Networkinstance.
+func (in *Networkinstance) DeepCopy() *Networkinstance {
+	if in == nil {
+		return nil
+	}
+	out := new(Networkinstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkinstanceInterface) DeepCopyInto(out *NetworkinstanceInterface) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.Subinterface != nil {
+		in, out := &in.Subinterface, &out.Subinterface
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkinstanceInterface.
+func (in *NetworkinstanceInterface) DeepCopy() *NetworkinstanceInterface {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkinstanceInterface)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Bgp) DeepCopyInto(out *Bgp) {
+	*out = *in
+	if in.AutonomousSystem != nil {
+		in, out := &in.AutonomousSystem, &out.AutonomousSystem
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.Neighbor != nil {
+		in, out := &in.Neighbor, &out.Neighbor
+		*out = make([]*BgpNeighbor, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(BgpNeighbor)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Bgp.
+func (in *Bgp) DeepCopy() *Bgp {
+	if in == nil {
+		return nil
+	}
+	out := new(Bgp)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BgpNeighbor) DeepCopyInto(out *BgpNeighbor) {
+	*out = *in
+	if in.ExportPolicy != nil {
+		in, out := &in.ExportPolicy, &out.ExportPolicy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PeerAddress != nil {
+		in, out := &in.PeerAddress, &out.PeerAddress
+		*out = new(string)
+		**out = **in
+	}
+	if in.PeerAs != nil {
+		in, out := &in.PeerAs, &out.PeerAs
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BgpNeighbor.
+func (in *BgpNeighbor) DeepCopy() *BgpNeighbor {
+	if in == nil {
+		return nil
+	}
+	out := new(BgpNeighbor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SampleInterfacesInterfaceParameters) DeepCopyInto(out *SampleInterfacesInterfaceParameters) {
+	*out = *in
+	if in.SampleInterfacesInterface != nil {
+		in, out := &in.SampleInterfacesInterface, &out.SampleInterfacesInterface
+		*out = new(Interface)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SampleInterfacesInterfaceParameters.
+func (in *SampleInterfacesInterfaceParameters) DeepCopy() *SampleInterfacesInterfaceParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(SampleInterfacesInterfaceParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SampleInterfacesInterfaceObservation) DeepCopyInto(out *SampleInterfacesInterfaceObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SampleInterfacesInterfaceObservation.
+func (in *SampleInterfacesInterfaceObservation) DeepCopy() *SampleInterfacesInterfaceObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(SampleInterfacesInterfaceObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SampleInterfacesInterfaceSpec) DeepCopyInto(out *SampleInterfacesInterfaceSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForNetworkNode.DeepCopyInto(&out.ForNetworkNode)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SampleInterfacesInterfaceSpec.
+func (in *SampleInterfacesInterfaceSpec) DeepCopy() *SampleInterfacesInterfaceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SampleInterfacesInterfaceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SampleInterfacesInterfaceStatus) DeepCopyInto(out *SampleInterfacesInterfaceStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	out.AtNetworkNode = in.AtNetworkNode
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SampleInterfacesInterfaceStatus.
+func (in *SampleInterfacesInterfaceStatus) DeepCopy() *SampleInterfacesInterfaceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SampleInterfacesInterfaceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SampleInterfacesInterface) DeepCopyInto(out *SampleInterfacesInterface) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SampleInterfacesInterface.
+func (in *SampleInterfacesInterface) DeepCopy() *SampleInterfacesInterface {
+	if in == nil {
+		return nil
+	}
+	out := new(SampleInterfacesInterface)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SampleInterfacesInterface) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SampleInterfacesInterfaceList) DeepCopyInto(out *SampleInterfacesInterfaceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SampleInterfacesInterface, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SampleInterfacesInterfaceList.
+func (in *SampleInterfacesInterfaceList) DeepCopy() *SampleInterfacesInterfaceList {
+	if in == nil {
+		return nil
+	}
+	out := new(SampleInterfacesInterfaceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SampleInterfacesInterfaceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SampleInterfacesInterfaceSubinterfaceParameters) DeepCopyInto(out *SampleInterfacesInterfaceSubinterfaceParameters) {
+	*out = *in
+	if in.SampleInterfaceName != nil {
+		in, out := &in.SampleInterfaceName, &out.SampleInterfaceName
+		*out = new(string)
+		**out = **in
+	}
+	if in.SampleInterfacesInterfaceSubinterface != nil {
+		in, out := &in.SampleInterfacesInterfaceSubinterface, &out.SampleInterfacesInterfaceSubinterface
+		*out = new(Subinterface)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SampleInterfacesInterfaceSubinterfaceParameters.
+func (in *SampleInterfacesInterfaceSubinterfaceParameters) DeepCopy() *SampleInterfacesInterfaceSubinterfaceParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(SampleInterfacesInterfaceSubinterfaceParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SampleInterfacesInterfaceSubinterfaceObservation) DeepCopyInto(out *SampleInterfacesInterfaceSubinterfaceObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SampleInterfacesInterfaceSubinterfaceObservation.
+func (in *SampleInterfacesInterfaceSubinterfaceObservation) DeepCopy() *SampleInterfacesInterfaceSubinterfaceObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(SampleInterfacesInterfaceSubinterfaceObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SampleInterfacesInterfaceSubinterfaceSpec) DeepCopyInto(out *SampleInterfacesInterfaceSubinterfaceSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForNetworkNode.DeepCopyInto(&out.ForNetworkNode)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SampleInterfacesInterfaceSubinterfaceSpec.
+func (in *SampleInterfacesInterfaceSubinterfaceSpec) DeepCopy() *SampleInterfacesInterfaceSubinterfaceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SampleInterfacesInterfaceSubinterfaceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SampleInterfacesInterfaceSubinterfaceStatus) DeepCopyInto(out *SampleInterfacesInterfaceSubinterfaceStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	out.AtNetworkNode = in.AtNetworkNode
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SampleInterfacesInterfaceSubinterfaceStatus.
+func (in *SampleInterfacesInterfaceSubinterfaceStatus) DeepCopy() *SampleInterfacesInterfaceSubinterfaceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SampleInterfacesInterfaceSubinterfaceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SampleInterfacesInterfaceSubinterface) DeepCopyInto(out *SampleInterfacesInterfaceSubinterface) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SampleInterfacesInterfaceSubinterface.
+func (in *SampleInterfacesInterfaceSubinterface) DeepCopy() *SampleInterfacesInterfaceSubinterface {
+	if in == nil {
+		return nil
+	}
+	out := new(SampleInterfacesInterfaceSubinterface)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SampleInterfacesInterfaceSubinterface) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SampleInterfacesInterfaceSubinterfaceList) DeepCopyInto(out *SampleInterfacesInterfaceSubinterfaceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SampleInterfacesInterfaceSubinterface, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SampleInterfacesInterfaceSubinterfaceList.
+func (in *SampleInterfacesInterfaceSubinterfaceList) DeepCopy() *SampleInterfacesInterfaceSubinterfaceList {
+	if in == nil {
+		return nil
+	}
+	out := new(SampleInterfacesInterfaceSubinterfaceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SampleInterfacesInterfaceSubinterfaceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SampleNetworkinstancesNetworkinstanceParameters) DeepCopyInto(out *SampleNetworkinstancesNetworkinstanceParameters) {
+	*out = *in
+	if in.SampleNetworkinstancesNetworkinstance != nil {
+		in, out := &in.SampleNetworkinstancesNetworkinstance, &out.SampleNetworkinstancesNetworkinstance
+		*out = new(Networkinstance)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SampleNetworkinstancesNetworkinstanceParameters.
+func (in *SampleNetworkinstancesNetworkinstanceParameters) DeepCopy() *SampleNetworkinstancesNetworkinstanceParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(SampleNetworkinstancesNetworkinstanceParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SampleNetworkinstancesNetworkinstanceObservation) DeepCopyInto(out *SampleNetworkinstancesNetworkinstanceObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SampleNetworkinstancesNetworkinstanceObservation.
+func (in *SampleNetworkinstancesNetworkinstanceObservation) DeepCopy() *SampleNetworkinstancesNetworkinstanceObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(SampleNetworkinstancesNetworkinstanceObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SampleNetworkinstancesNetworkinstanceSpec) DeepCopyInto(out *SampleNetworkinstancesNetworkinstanceSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForNetworkNode.DeepCopyInto(&out.ForNetworkNode)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SampleNetworkinstancesNetworkinstanceSpec.
+func (in *SampleNetworkinstancesNetworkinstanceSpec) DeepCopy() *SampleNetworkinstancesNetworkinstanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SampleNetworkinstancesNetworkinstanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SampleNetworkinstancesNetworkinstanceStatus) DeepCopyInto(out *SampleNetworkinstancesNetworkinstanceStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	out.AtNetworkNode = in.AtNetworkNode
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SampleNetworkinstancesNetworkinstanceStatus.
+func (in *SampleNetworkinstancesNetworkinstanceStatus) DeepCopy() *SampleNetworkinstancesNetworkinstanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SampleNetworkinstancesNetworkinstanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SampleNetworkinstancesNetworkinstance) DeepCopyInto(out *SampleNetworkinstancesNetworkinstance) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SampleNetworkinstancesNetworkinstance.
+func (in *SampleNetworkinstancesNetworkinstance) DeepCopy() *SampleNetworkinstancesNetworkinstance {
+	if in == nil {
+		return nil
+	}
+	out := new(SampleNetworkinstancesNetworkinstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SampleNetworkinstancesNetworkinstance) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SampleNetworkinstancesNetworkinstanceList) DeepCopyInto(out *SampleNetworkinstancesNetworkinstanceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SampleNetworkinstancesNetworkinstance, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SampleNetworkinstancesNetworkinstanceList.
+func (in *SampleNetworkinstancesNetworkinstanceList) DeepCopy() *SampleNetworkinstancesNetworkinstanceList {
+	if in == nil {
+		return nil
+	}
+	out := new(SampleNetworkinstancesNetworkinstanceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SampleNetworkinstancesNetworkinstanceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SampleNetworkinstancesNetworkinstanceProtocolsBgpParameters) DeepCopyInto(out *SampleNetworkinstancesNetworkinstanceProtocolsBgpParameters) {
+	*out = *in
+	if in.SampleNetworkInstanceName != nil {
+		in, out := &in.SampleNetworkInstanceName, &out.SampleNetworkInstanceName
+		*out = new(string)
+		**out = **in
+	}
+	if in.SampleNetworkinstancesNetworkinstanceProtocolsBgp != nil {
+		in, out := &in.SampleNetworkinstancesNetworkinstanceProtocolsBgp, &out.SampleNetworkinstancesNetworkinstanceProtocolsBgp
+		*out = new(Bgp)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SampleNetworkinstancesNetworkinstanceProtocolsBgpParameters.
+func (in *SampleNetworkinstancesNetworkinstanceProtocolsBgpParameters) DeepCopy() *SampleNetworkinstancesNetworkinstanceProtocolsBgpParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(SampleNetworkinstancesNetworkinstanceProtocolsBgpParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SampleNetworkinstancesNetworkinstanceProtocolsBgpObservation) DeepCopyInto(out *SampleNetworkinstancesNetworkinstanceProtocolsBgpObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SampleNetworkinstancesNetworkinstanceProtocolsBgpObservation.
+func (in *SampleNetworkinstancesNetworkinstanceProtocolsBgpObservation) DeepCopy() *SampleNetworkinstancesNetworkinstanceProtocolsBgpObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(SampleNetworkinstancesNetworkinstanceProtocolsBgpObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SampleNetworkinstancesNetworkinstanceProtocolsBgpSpec) DeepCopyInto(out *SampleNetworkinstancesNetworkinstanceProtocolsBgpSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForNetworkNode.DeepCopyInto(&out.ForNetworkNode)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SampleNetworkinstancesNetworkinstanceProtocolsBgpSpec.
+func (in *SampleNetworkinstancesNetworkinstanceProtocolsBgpSpec) DeepCopy() *SampleNetworkinstancesNetworkinstanceProtocolsBgpSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SampleNetworkinstancesNetworkinstanceProtocolsBgpSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SampleNetworkinstancesNetworkinstanceProtocolsBgpStatus) DeepCopyInto(out *SampleNetworkinstancesNetworkinstanceProtocolsBgpStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	out.AtNetworkNode = in.AtNetworkNode
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SampleNetworkinstancesNetworkinstanceProtocolsBgpStatus.
+func (in *SampleNetworkinstancesNetworkinstanceProtocolsBgpStatus) DeepCopy() *SampleNetworkinstancesNetworkinstanceProtocolsBgpStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SampleNetworkinstancesNetworkinstanceProtocolsBgpStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SampleNetworkinstancesNetworkinstanceProtocolsBgp) DeepCopyInto(out *SampleNetworkinstancesNetworkinstanceProtocolsBgp) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SampleNetworkinstancesNetworkinstanceProtocolsBgp.
+func (in *SampleNetworkinstancesNetworkinstanceProtocolsBgp) DeepCopy() *SampleNetworkinstancesNetworkinstanceProtocolsBgp {
+	if in == nil {
+		return nil
+	}
+	out := new(SampleNetworkinstancesNetworkinstanceProtocolsBgp)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SampleNetworkinstancesNetworkinstanceProtocolsBgp) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SampleNetworkinstancesNetworkinstanceProtocolsBgpList) DeepCopyInto(out *SampleNetworkinstancesNetworkinstanceProtocolsBgpList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SampleNetworkinstancesNetworkinstanceProtocolsBgp, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SampleNetworkinstancesNetworkinstanceProtocolsBgpList.
+func (in *SampleNetworkinstancesNetworkinstanceProtocolsBgpList) DeepCopy() *SampleNetworkinstancesNetworkinstanceProtocolsBgpList {
+	if in == nil {
+		return nil
+	}
+	out := new(SampleNetworkinstancesNetworkinstanceProtocolsBgpList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SampleNetworkinstancesNetworkinstanceProtocolsBgpList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
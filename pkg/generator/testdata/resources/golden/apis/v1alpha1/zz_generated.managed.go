@@ -0,0 +1,340 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by ndd-ygen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	nddv1 "github.com/yndd/ndd-runtime/apis/common/v1"
+	"github.com/yndd/ndd-runtime/pkg/resource"
+)
+
+// GetActive of this SampleInterfacesInterface.
+func (mg *SampleInterfacesInterface) GetActive() bool {
+	return mg.Spec.Active
+}
+
+// GetCondition of this SampleInterfacesInterface.
+func (mg *SampleInterfacesInterface) GetCondition(ck nddv1.ConditionKind) nddv1.Condition {
+	return mg.Status.GetCondition(ck)
+}
+
+// GetDeletionPolicy of this SampleInterfacesInterface.
+func (mg *SampleInterfacesInterface) GetDeletionPolicy() nddv1.DeletionPolicy {
+	return mg.Spec.DeletionPolicy
+}
+
+// GetExternalLeafRefs of this SampleInterfacesInterface.
+func (mg *SampleInterfacesInterface) GetExternalLeafRefs() []string {
+	return mg.Status.ExternalLeafRefs
+}
+
+// GetNetworkNodeReference of this SampleInterfacesInterface.
+func (mg *SampleInterfacesInterface) GetNetworkNodeReference() *nddv1.Reference {
+	return mg.Spec.NetworkNodeReference
+}
+
+// GetResourceIndexes of this SampleInterfacesInterface.
+func (mg *SampleInterfacesInterface) GetResourceIndexes() map[string]string {
+	return mg.Status.ResourceIndexes
+}
+
+// GetTarget of this SampleInterfacesInterface.
+func (mg *SampleInterfacesInterface) GetTarget() []string {
+	return mg.Status.Target
+}
+
+// SetActive of this SampleInterfacesInterface.
+func (mg *SampleInterfacesInterface) SetActive(b bool) {
+	mg.Spec.Active = b
+}
+
+// SetConditions of this SampleInterfacesInterface.
+func (mg *SampleInterfacesInterface) SetConditions(c ...nddv1.Condition) {
+	mg.Status.SetConditions(c...)
+}
+
+// SetDeletionPolicy of this SampleInterfacesInterface.
+func (mg *SampleInterfacesInterface) SetDeletionPolicy(r nddv1.DeletionPolicy) {
+	mg.Spec.DeletionPolicy = r
+}
+
+// SetExternalLeafRefs of this SampleInterfacesInterface.
+func (mg *SampleInterfacesInterface) SetExternalLeafRefs(n []string) {
+	mg.Status.ExternalLeafRefs = n
+}
+
+// SetNetworkNodeReference of this SampleInterfacesInterface.
+func (mg *SampleInterfacesInterface) SetNetworkNodeReference(r *nddv1.Reference) {
+	mg.Spec.NetworkNodeReference = r
+}
+
+// SetResourceIndexes of this SampleInterfacesInterface.
+func (mg *SampleInterfacesInterface) SetResourceIndexes(n map[string]string) {
+	mg.Status.ResourceIndexes = n
+}
+
+// SetTarget of this SampleInterfacesInterface.
+func (mg *SampleInterfacesInterface) SetTarget(t []string) {
+	mg.Status.Target = t
+}
+
+// GetItems of this SampleInterfacesInterfaceList.
+func (l *SampleInterfacesInterfaceList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
+
+// GetActive of this SampleInterfacesInterfaceSubinterface.
+func (mg *SampleInterfacesInterfaceSubinterface) GetActive() bool {
+	return mg.Spec.Active
+}
+
+// GetCondition of this SampleInterfacesInterfaceSubinterface.
+func (mg *SampleInterfacesInterfaceSubinterface) GetCondition(ck nddv1.ConditionKind) nddv1.Condition {
+	return mg.Status.GetCondition(ck)
+}
+
+// GetDeletionPolicy of this SampleInterfacesInterfaceSubinterface.
+func (mg *SampleInterfacesInterfaceSubinterface) GetDeletionPolicy() nddv1.DeletionPolicy {
+	return mg.Spec.DeletionPolicy
+}
+
+// GetExternalLeafRefs of this SampleInterfacesInterfaceSubinterface.
+func (mg *SampleInterfacesInterfaceSubinterface) GetExternalLeafRefs() []string {
+	return mg.Status.ExternalLeafRefs
+}
+
+// GetNetworkNodeReference of this SampleInterfacesInterfaceSubinterface.
+func (mg *SampleInterfacesInterfaceSubinterface) GetNetworkNodeReference() *nddv1.Reference {
+	return mg.Spec.NetworkNodeReference
+}
+
+// GetResourceIndexes of this SampleInterfacesInterfaceSubinterface.
+func (mg *SampleInterfacesInterfaceSubinterface) GetResourceIndexes() map[string]string {
+	return mg.Status.ResourceIndexes
+}
+
+// GetTarget of this SampleInterfacesInterfaceSubinterface.
+func (mg *SampleInterfacesInterfaceSubinterface) GetTarget() []string {
+	return mg.Status.Target
+}
+
+// SetActive of this SampleInterfacesInterfaceSubinterface.
+func (mg *SampleInterfacesInterfaceSubinterface) SetActive(b bool) {
+	mg.Spec.Active = b
+}
+
+// SetConditions of this SampleInterfacesInterfaceSubinterface.
+func (mg *SampleInterfacesInterfaceSubinterface) SetConditions(c ...nddv1.Condition) {
+	mg.Status.SetConditions(c...)
+}
+
+// SetDeletionPolicy of this SampleInterfacesInterfaceSubinterface.
+func (mg *SampleInterfacesInterfaceSubinterface) SetDeletionPolicy(r nddv1.DeletionPolicy) {
+	mg.Spec.DeletionPolicy = r
+}
+
+// SetExternalLeafRefs of this SampleInterfacesInterfaceSubinterface.
+func (mg *SampleInterfacesInterfaceSubinterface) SetExternalLeafRefs(n []string) {
+	mg.Status.ExternalLeafRefs = n
+}
+
+// SetNetworkNodeReference of this SampleInterfacesInterfaceSubinterface.
+func (mg *SampleInterfacesInterfaceSubinterface) SetNetworkNodeReference(r *nddv1.Reference) {
+	mg.Spec.NetworkNodeReference = r
+}
+
+// SetResourceIndexes of this SampleInterfacesInterfaceSubinterface.
+func (mg *SampleInterfacesInterfaceSubinterface) SetResourceIndexes(n map[string]string) {
+	mg.Status.ResourceIndexes = n
+}
+
+// SetTarget of this SampleInterfacesInterfaceSubinterface.
+func (mg *SampleInterfacesInterfaceSubinterface) SetTarget(t []string) {
+	mg.Status.Target = t
+}
+
+// GetItems of this SampleInterfacesInterfaceSubinterfaceList.
+func (l *SampleInterfacesInterfaceSubinterfaceList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
+
+// GetActive of this SampleNetworkinstancesNetworkinstance.
+func (mg *SampleNetworkinstancesNetworkinstance) GetActive() bool {
+	return mg.Spec.Active
+}
+
+// GetCondition of this SampleNetworkinstancesNetworkinstance.
+func (mg *SampleNetworkinstancesNetworkinstance) GetCondition(ck nddv1.ConditionKind) nddv1.Condition {
+	return mg.Status.GetCondition(ck)
+}
+
+// GetDeletionPolicy of this SampleNetworkinstancesNetworkinstance.
+func (mg *SampleNetworkinstancesNetworkinstance) GetDeletionPolicy() nddv1.DeletionPolicy {
+	return mg.Spec.DeletionPolicy
+}
+
+// GetExternalLeafRefs of this SampleNetworkinstancesNetworkinstance.
+func (mg *SampleNetworkinstancesNetworkinstance) GetExternalLeafRefs() []string {
+	return mg.Status.ExternalLeafRefs
+}
+
+// GetNetworkNodeReference of this SampleNetworkinstancesNetworkinstance.
+func (mg *SampleNetworkinstancesNetworkinstance) GetNetworkNodeReference() *nddv1.Reference {
+	return mg.Spec.NetworkNodeReference
+}
+
+// GetResourceIndexes of this SampleNetworkinstancesNetworkinstance.
+func (mg *SampleNetworkinstancesNetworkinstance) GetResourceIndexes() map[string]string {
+	return mg.Status.ResourceIndexes
+}
+
+// GetTarget of this SampleNetworkinstancesNetworkinstance.
+func (mg *SampleNetworkinstancesNetworkinstance) GetTarget() []string {
+	return mg.Status.Target
+}
+
+// SetActive of this SampleNetworkinstancesNetworkinstance.
+func (mg *SampleNetworkinstancesNetworkinstance) SetActive(b bool) {
+	mg.Spec.Active = b
+}
+
+// SetConditions of this SampleNetworkinstancesNetworkinstance.
+func (mg *SampleNetworkinstancesNetworkinstance) SetConditions(c ...nddv1.Condition) {
+	mg.Status.SetConditions(c...)
+}
+
+// SetDeletionPolicy of this SampleNetworkinstancesNetworkinstance.
+func (mg *SampleNetworkinstancesNetworkinstance) SetDeletionPolicy(r nddv1.DeletionPolicy) {
+	mg.Spec.DeletionPolicy = r
+}
+
+// SetExternalLeafRefs of this SampleNetworkinstancesNetworkinstance.
+func (mg *SampleNetworkinstancesNetworkinstance) SetExternalLeafRefs(n []string) {
+	mg.Status.ExternalLeafRefs = n
+}
+
+// SetNetworkNodeReference of this SampleNetworkinstancesNetworkinstance.
+func (mg *SampleNetworkinstancesNetworkinstance) SetNetworkNodeReference(r *nddv1.Reference) {
+	mg.Spec.NetworkNodeReference = r
+}
+
+// SetResourceIndexes of this SampleNetworkinstancesNetworkinstance.
+func (mg *SampleNetworkinstancesNetworkinstance) SetResourceIndexes(n map[string]string) {
+	mg.Status.ResourceIndexes = n
+}
+
+// SetTarget of this SampleNetworkinstancesNetworkinstance.
+func (mg *SampleNetworkinstancesNetworkinstance) SetTarget(t []string) {
+	mg.Status.Target = t
+}
+
+// GetItems of this SampleNetworkinstancesNetworkinstanceList.
+func (l *SampleNetworkinstancesNetworkinstanceList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
+
+// GetActive of this SampleNetworkinstancesNetworkinstanceProtocolsBgp.
+func (mg *SampleNetworkinstancesNetworkinstanceProtocolsBgp) GetActive() bool {
+	return mg.Spec.Active
+}
+
+// GetCondition of this SampleNetworkinstancesNetworkinstanceProtocolsBgp.
+func (mg *SampleNetworkinstancesNetworkinstanceProtocolsBgp) GetCondition(ck nddv1.ConditionKind) nddv1.Condition {
+	return mg.Status.GetCondition(ck)
+}
+
+// GetDeletionPolicy of this SampleNetworkinstancesNetworkinstanceProtocolsBgp.
+func (mg *SampleNetworkinstancesNetworkinstanceProtocolsBgp) GetDeletionPolicy() nddv1.DeletionPolicy {
+	return mg.Spec.DeletionPolicy
+}
+
+// GetExternalLeafRefs of this SampleNetworkinstancesNetworkinstanceProtocolsBgp.
+func (mg *SampleNetworkinstancesNetworkinstanceProtocolsBgp) GetExternalLeafRefs() []string {
+	return mg.Status.ExternalLeafRefs
+}
+
+// GetNetworkNodeReference of this SampleNetworkinstancesNetworkinstanceProtocolsBgp.
+func (mg *SampleNetworkinstancesNetworkinstanceProtocolsBgp) GetNetworkNodeReference() *nddv1.Reference {
+	return mg.Spec.NetworkNodeReference
+}
+
+// GetResourceIndexes of this SampleNetworkinstancesNetworkinstanceProtocolsBgp.
+func (mg *SampleNetworkinstancesNetworkinstanceProtocolsBgp) GetResourceIndexes() map[string]string {
+	return mg.Status.ResourceIndexes
+}
+
+// GetTarget of this SampleNetworkinstancesNetworkinstanceProtocolsBgp.
+func (mg *SampleNetworkinstancesNetworkinstanceProtocolsBgp) GetTarget() []string {
+	return mg.Status.Target
+}
+
+// SetActive of this SampleNetworkinstancesNetworkinstanceProtocolsBgp.
+func (mg *SampleNetworkinstancesNetworkinstanceProtocolsBgp) SetActive(b bool) {
+	mg.Spec.Active = b
+}
+
+// SetConditions of this SampleNetworkinstancesNetworkinstanceProtocolsBgp.
+func (mg *SampleNetworkinstancesNetworkinstanceProtocolsBgp) SetConditions(c ...nddv1.Condition) {
+	mg.Status.SetConditions(c...)
+}
+
+// SetDeletionPolicy of this SampleNetworkinstancesNetworkinstanceProtocolsBgp.
+func (mg *SampleNetworkinstancesNetworkinstanceProtocolsBgp) SetDeletionPolicy(r nddv1.DeletionPolicy) {
+	mg.Spec.DeletionPolicy = r
+}
+
+// SetExternalLeafRefs of this SampleNetworkinstancesNetworkinstanceProtocolsBgp.
+func (mg *SampleNetworkinstancesNetworkinstanceProtocolsBgp) SetExternalLeafRefs(n []string) {
+	mg.Status.ExternalLeafRefs = n
+}
+
+// SetNetworkNodeReference of this SampleNetworkinstancesNetworkinstanceProtocolsBgp.
+func (mg *SampleNetworkinstancesNetworkinstanceProtocolsBgp) SetNetworkNodeReference(r *nddv1.Reference) {
+	mg.Spec.NetworkNodeReference = r
+}
+
+// SetResourceIndexes of this SampleNetworkinstancesNetworkinstanceProtocolsBgp.
+func (mg *SampleNetworkinstancesNetworkinstanceProtocolsBgp) SetResourceIndexes(n map[string]string) {
+	mg.Status.ResourceIndexes = n
+}
+
+// SetTarget of this SampleNetworkinstancesNetworkinstanceProtocolsBgp.
+func (mg *SampleNetworkinstancesNetworkinstanceProtocolsBgp) SetTarget(t []string) {
+	mg.Status.Target = t
+}
+
+// GetItems of this SampleNetworkinstancesNetworkinstanceProtocolsBgpList.
+func (l *SampleNetworkinstancesNetworkinstanceProtocolsBgpList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
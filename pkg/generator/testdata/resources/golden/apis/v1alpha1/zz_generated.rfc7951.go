@@ -0,0 +1,739 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by ndd-ygen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MarshalRFC7951 returns the RFC 7951 JSON encoding of the SampleInterfacesInterface resource,
+// the JSON object of the data node at the path of the resource.
+func (in *SampleInterfacesInterfaceParameters) MarshalRFC7951() ([]byte, error) {
+	if in.SampleInterfacesInterface == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(in.SampleInterfacesInterface.toRFC7951())
+}
+
+// UnmarshalRFC7951 sets the SampleInterfacesInterface resource from the RFC 7951 JSON encoding of
+// the data node at the path of the resource, the members that are not part of the
+// resource are ignored.
+func (out *SampleInterfacesInterfaceParameters) UnmarshalRFC7951(b []byte) error {
+	in, err := rfc7951Decode(b)
+	if err != nil {
+		return err
+	}
+	out.SampleInterfacesInterface = new(Interface)
+	return out.SampleInterfacesInterface.fromRFC7951(in)
+}
+
+// MarshalRFC7951 returns the RFC 7951 JSON encoding of the SampleInterfacesInterfaceSubinterface resource,
+// the JSON object of the data node at the path of the resource.
+func (in *SampleInterfacesInterfaceSubinterfaceParameters) MarshalRFC7951() ([]byte, error) {
+	if in.SampleInterfacesInterfaceSubinterface == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(in.SampleInterfacesInterfaceSubinterface.toRFC7951())
+}
+
+// UnmarshalRFC7951 sets the SampleInterfacesInterfaceSubinterface resource from the RFC 7951 JSON encoding of
+// the data node at the path of the resource, the members that are not part of the
+// resource are ignored.
+func (out *SampleInterfacesInterfaceSubinterfaceParameters) UnmarshalRFC7951(b []byte) error {
+	in, err := rfc7951Decode(b)
+	if err != nil {
+		return err
+	}
+	out.SampleInterfacesInterfaceSubinterface = new(Subinterface)
+	return out.SampleInterfacesInterfaceSubinterface.fromRFC7951(in)
+}
+
+// MarshalRFC7951 returns the RFC 7951 JSON encoding of the SampleNetworkinstancesNetworkinstance resource,
+// the JSON object of the data node at the path of the resource.
+func (in *SampleNetworkinstancesNetworkinstanceParameters) MarshalRFC7951() ([]byte, error) {
+	if in.SampleNetworkinstancesNetworkinstance == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(in.SampleNetworkinstancesNetworkinstance.toRFC7951())
+}
+
+// UnmarshalRFC7951 sets the SampleNetworkinstancesNetworkinstance resource from the RFC 7951 JSON encoding of
+// the data node at the path of the resource, the members that are not part of the
+// resource are ignored.
+func (out *SampleNetworkinstancesNetworkinstanceParameters) UnmarshalRFC7951(b []byte) error {
+	in, err := rfc7951Decode(b)
+	if err != nil {
+		return err
+	}
+	out.SampleNetworkinstancesNetworkinstance = new(Networkinstance)
+	return out.SampleNetworkinstancesNetworkinstance.fromRFC7951(in)
+}
+
+// MarshalRFC7951 returns the RFC 7951 JSON encoding of the SampleNetworkinstancesNetworkinstanceProtocolsBgp resource,
+// the JSON object of the data node at the path of the resource.
+func (in *SampleNetworkinstancesNetworkinstanceProtocolsBgpParameters) MarshalRFC7951() ([]byte, error) {
+	if in.SampleNetworkinstancesNetworkinstanceProtocolsBgp == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(in.SampleNetworkinstancesNetworkinstanceProtocolsBgp.toRFC7951())
+}
+
+// UnmarshalRFC7951 sets the SampleNetworkinstancesNetworkinstanceProtocolsBgp resource from the RFC 7951 JSON encoding of
+// the data node at the path of the resource, the members that are not part of the
+// resource are ignored.
+func (out *SampleNetworkinstancesNetworkinstanceProtocolsBgpParameters) UnmarshalRFC7951(b []byte) error {
+	in, err := rfc7951Decode(b)
+	if err != nil {
+		return err
+	}
+	out.SampleNetworkinstancesNetworkinstanceProtocolsBgp = new(Bgp)
+	return out.SampleNetworkinstancesNetworkinstanceProtocolsBgp.fromRFC7951(in)
+}
+
+// rfc7951InterfaceEncapTypeIdentities holds the modules of the identities of Interface.EncapType,
+// the identities that are defined by multiple modules keep the module qualifier in the field
+var rfc7951InterfaceEncapTypeIdentities = map[string]string{
+	"dot1q":    "sample-types",
+	"untagged": "sample-types",
+}
+
+// toRFC7951 returns the RFC 7951 JSON object of the Interface
+func (in *Interface) toRFC7951() map[string]interface{} {
+	out := map[string]interface{}{}
+	if in.AdminState != nil {
+		out["admin-state"] = *in.AdminState
+	}
+	if in.Description != nil {
+		out["description"] = *in.Description
+	}
+	if in.Untagged != nil {
+		out["untagged"] = []interface{}{nil}
+	}
+	if in.VlanId != nil {
+		out["vlan-id"] = *in.VlanId
+	}
+	if in.EncapType != nil {
+		out["encap-type"] = rfc7951EncodeIdentity(*in.EncapType, rfc7951InterfaceEncapTypeIdentities)
+	}
+	if in.Name != nil {
+		out["name"] = *in.Name
+	}
+	if in.Tags != nil {
+		values := make([]interface{}, 0, len(in.Tags))
+		for _, v := range in.Tags {
+			values = append(values, v)
+		}
+		out["tags"] = values
+	}
+	return out
+}
+
+// fromRFC7951 sets the Interface from the RFC 7951 JSON object
+func (out *Interface) fromRFC7951(in map[string]interface{}) error {
+	switch v, ok, err := rfc7951Member(in, "admin-state"); {
+	case err != nil:
+		return err
+	case ok:
+		x, err := rfc7951String(v, "admin-state")
+		if err != nil {
+			return err
+		}
+		out.AdminState = &x
+	}
+	switch v, ok, err := rfc7951Member(in, "description"); {
+	case err != nil:
+		return err
+	case ok:
+		x, err := rfc7951String(v, "description")
+		if err != nil {
+			return err
+		}
+		out.Description = &x
+	}
+	switch v, ok, err := rfc7951Member(in, "untagged"); {
+	case err != nil:
+		return err
+	case ok:
+		if err := rfc7951Empty(v, "untagged"); err != nil {
+			return err
+		}
+		x := ""
+		out.Untagged = &x
+	}
+	switch v, ok, err := rfc7951Member(in, "vlan-id"); {
+	case err != nil:
+		return err
+	case ok:
+		i, err := rfc7951Uint(v, "vlan-id", 16)
+		if err != nil {
+			return err
+		}
+		x := uint16(i)
+		out.VlanId = &x
+	}
+	switch v, ok, err := rfc7951Member(in, "encap-type"); {
+	case err != nil:
+		return err
+	case ok:
+		x, err := rfc7951String(v, "encap-type")
+		if err != nil {
+			return err
+		}
+		x = rfc7951DecodeIdentity(x, rfc7951InterfaceEncapTypeIdentities)
+		out.EncapType = &x
+	}
+	switch v, ok, err := rfc7951Member(in, "name"); {
+	case err != nil:
+		return err
+	case ok:
+		x, err := rfc7951String(v, "name")
+		if err != nil {
+			return err
+		}
+		out.Name = &x
+	}
+	switch v, ok, err := rfc7951Member(in, "tags"); {
+	case err != nil:
+		return err
+	case ok:
+		values, err := rfc7951Array(v, "tags")
+		if err != nil {
+			return err
+		}
+		out.Tags = make([]string, 0, len(values))
+		for _, v := range values {
+			x, err := rfc7951String(v, "tags")
+			if err != nil {
+				return err
+			}
+			out.Tags = append(out.Tags, x)
+		}
+	}
+	return nil
+}
+
+// toRFC7951 returns the RFC 7951 JSON object of the Subinterface
+func (in *Subinterface) toRFC7951() map[string]interface{} {
+	out := map[string]interface{}{}
+	if in.Description != nil {
+		out["description"] = *in.Description
+	}
+	if in.Index != nil {
+		out["index"] = *in.Index
+	}
+	if in.Ipv4 != nil {
+		out["ipv4"] = in.Ipv4.toRFC7951()
+	}
+	return out
+}
+
+// fromRFC7951 sets the Subinterface from the RFC 7951 JSON object
+func (out *Subinterface) fromRFC7951(in map[string]interface{}) error {
+	switch v, ok, err := rfc7951Member(in, "description"); {
+	case err != nil:
+		return err
+	case ok:
+		x, err := rfc7951String(v, "description")
+		if err != nil {
+			return err
+		}
+		out.Description = &x
+	}
+	switch v, ok, err := rfc7951Member(in, "index"); {
+	case err != nil:
+		return err
+	case ok:
+		i, err := rfc7951Uint(v, "index", 32)
+		if err != nil {
+			return err
+		}
+		x := uint32(i)
+		out.Index = &x
+	}
+	switch v, ok, err := rfc7951Member(in, "ipv4"); {
+	case err != nil:
+		return err
+	case ok:
+		{
+			o, err := rfc7951Object(v, "ipv4")
+			if err != nil {
+				return err
+			}
+			out.Ipv4 = new(SubinterfaceIpv4)
+			if err := out.Ipv4.fromRFC7951(o); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// toRFC7951 returns the RFC 7951 JSON object of the SubinterfaceIpv4
+func (in *SubinterfaceIpv4) toRFC7951() map[string]interface{} {
+	out := map[string]interface{}{}
+	if in.Address != nil {
+		entries := make([]interface{}, 0, len(in.Address))
+		for _, e := range in.Address {
+			if e != nil {
+				entries = append(entries, e.toRFC7951())
+			}
+		}
+		out["address"] = entries
+	}
+	return out
+}
+
+// fromRFC7951 sets the SubinterfaceIpv4 from the RFC 7951 JSON object
+func (out *SubinterfaceIpv4) fromRFC7951(in map[string]interface{}) error {
+	switch v, ok, err := rfc7951Member(in, "address"); {
+	case err != nil:
+		return err
+	case ok:
+		entries, err := rfc7951Array(v, "address")
+		if err != nil {
+			return err
+		}
+		out.Address = make([]*SubinterfaceIpv4Address, 0, len(entries))
+		for _, e := range entries {
+			o, err := rfc7951Object(e, "address")
+			if err != nil {
+				return err
+			}
+			x := new(SubinterfaceIpv4Address)
+			if err := x.fromRFC7951(o); err != nil {
+				return err
+			}
+			out.Address = append(out.Address, x)
+		}
+	}
+	return nil
+}
+
+// toRFC7951 returns the RFC 7951 JSON object of the SubinterfaceIpv4Address
+func (in *SubinterfaceIpv4Address) toRFC7951() map[string]interface{} {
+	out := map[string]interface{}{}
+	if in.IpPrefix != nil {
+		out["ip-prefix"] = *in.IpPrefix
+	}
+	if in.Primary != nil {
+		out["primary"] = *in.Primary
+	}
+	return out
+}
+
+// fromRFC7951 sets the SubinterfaceIpv4Address from the RFC 7951 JSON object
+func (out *SubinterfaceIpv4Address) fromRFC7951(in map[string]interface{}) error {
+	switch v, ok, err := rfc7951Member(in, "ip-prefix"); {
+	case err != nil:
+		return err
+	case ok:
+		x, err := rfc7951String(v, "ip-prefix")
+		if err != nil {
+			return err
+		}
+		out.IpPrefix = &x
+	}
+	switch v, ok, err := rfc7951Member(in, "primary"); {
+	case err != nil:
+		return err
+	case ok:
+		x, err := rfc7951Bool(v, "primary")
+		if err != nil {
+			return err
+		}
+		out.Primary = &x
+	}
+	return nil
+}
+
+// toRFC7951 returns the RFC 7951 JSON object of the Networkinstance
+func (in *Networkinstance) toRFC7951() map[string]interface{} {
+	out := map[string]interface{}{}
+	if in.DefaultInterface != nil {
+		out["default-interface"] = *in.DefaultInterface
+	}
+	if in.Interface != nil {
+		entries := make([]interface{}, 0, len(in.Interface))
+		for _, e := range in.Interface {
+			if e != nil {
+				entries = append(entries, e.toRFC7951())
+			}
+		}
+		out["interface"] = entries
+	}
+	if in.Name != nil {
+		out["name"] = *in.Name
+	}
+	if in.RouterId != nil {
+		out["router-id"] = *in.RouterId
+	}
+	if in.Type != nil {
+		out["type"] = *in.Type
+	}
+	return out
+}
+
+// fromRFC7951 sets the Networkinstance from the RFC 7951 JSON object
+func (out *Networkinstance) fromRFC7951(in map[string]interface{}) error {
+	switch v, ok, err := rfc7951Member(in, "default-interface"); {
+	case err != nil:
+		return err
+	case ok:
+		x, err := rfc7951String(v, "default-interface")
+		if err != nil {
+			return err
+		}
+		out.DefaultInterface = &x
+	}
+	switch v, ok, err := rfc7951Member(in, "interface"); {
+	case err != nil:
+		return err
+	case ok:
+		entries, err := rfc7951Array(v, "interface")
+		if err != nil {
+			return err
+		}
+		out.Interface = make([]*NetworkinstanceInterface, 0, len(entries))
+		for _, e := range entries {
+			o, err := rfc7951Object(e, "interface")
+			if err != nil {
+				return err
+			}
+			x := new(NetworkinstanceInterface)
+			if err := x.fromRFC7951(o); err != nil {
+				return err
+			}
+			out.Interface = append(out.Interface, x)
+		}
+	}
+	switch v, ok, err := rfc7951Member(in, "name"); {
+	case err != nil:
+		return err
+	case ok:
+		x, err := rfc7951String(v, "name")
+		if err != nil {
+			return err
+		}
+		out.Name = &x
+	}
+	switch v, ok, err := rfc7951Member(in, "router-id"); {
+	case err != nil:
+		return err
+	case ok:
+		x, err := rfc7951String(v, "router-id")
+		if err != nil {
+			return err
+		}
+		out.RouterId = &x
+	}
+	switch v, ok, err := rfc7951Member(in, "type"); {
+	case err != nil:
+		return err
+	case ok:
+		x, err := rfc7951String(v, "type")
+		if err != nil {
+			return err
+		}
+		out.Type = &x
+	}
+	return nil
+}
+
+// toRFC7951 returns the RFC 7951 JSON object of the NetworkinstanceInterface
+func (in *NetworkinstanceInterface) toRFC7951() map[string]interface{} {
+	out := map[string]interface{}{}
+	if in.Name != nil {
+		out["name"] = *in.Name
+	}
+	if in.Subinterface != nil {
+		out["subinterface"] = *in.Subinterface
+	}
+	return out
+}
+
+// fromRFC7951 sets the NetworkinstanceInterface from the RFC 7951 JSON object
+func (out *NetworkinstanceInterface) fromRFC7951(in map[string]interface{}) error {
+	switch v, ok, err := rfc7951Member(in, "name"); {
+	case err != nil:
+		return err
+	case ok:
+		x, err := rfc7951String(v, "name")
+		if err != nil {
+			return err
+		}
+		out.Name = &x
+	}
+	switch v, ok, err := rfc7951Member(in, "subinterface"); {
+	case err != nil:
+		return err
+	case ok:
+		x, err := rfc7951String(v, "subinterface")
+		if err != nil {
+			return err
+		}
+		out.Subinterface = &x
+	}
+	return nil
+}
+
+// toRFC7951 returns the RFC 7951 JSON object of the Bgp
+func (in *Bgp) toRFC7951() map[string]interface{} {
+	out := map[string]interface{}{}
+	if in.AutonomousSystem != nil {
+		out["autonomous-system"] = *in.AutonomousSystem
+	}
+	if in.Neighbor != nil {
+		entries := make([]interface{}, 0, len(in.Neighbor))
+		for _, e := range in.Neighbor {
+			if e != nil {
+				entries = append(entries, e.toRFC7951())
+			}
+		}
+		out["neighbor"] = entries
+	}
+	return out
+}
+
+// fromRFC7951 sets the Bgp from the RFC 7951 JSON object
+func (out *Bgp) fromRFC7951(in map[string]interface{}) error {
+	switch v, ok, err := rfc7951Member(in, "autonomous-system"); {
+	case err != nil:
+		return err
+	case ok:
+		i, err := rfc7951Uint(v, "autonomous-system", 32)
+		if err != nil {
+			return err
+		}
+		x := uint32(i)
+		out.AutonomousSystem = &x
+	}
+	switch v, ok, err := rfc7951Member(in, "neighbor"); {
+	case err != nil:
+		return err
+	case ok:
+		entries, err := rfc7951Array(v, "neighbor")
+		if err != nil {
+			return err
+		}
+		out.Neighbor = make([]*BgpNeighbor, 0, len(entries))
+		for _, e := range entries {
+			o, err := rfc7951Object(e, "neighbor")
+			if err != nil {
+				return err
+			}
+			x := new(BgpNeighbor)
+			if err := x.fromRFC7951(o); err != nil {
+				return err
+			}
+			out.Neighbor = append(out.Neighbor, x)
+		}
+	}
+	return nil
+}
+
+// toRFC7951 returns the RFC 7951 JSON object of the BgpNeighbor
+func (in *BgpNeighbor) toRFC7951() map[string]interface{} {
+	out := map[string]interface{}{}
+	if in.ExportPolicy != nil {
+		values := make([]interface{}, 0, len(in.ExportPolicy))
+		for _, v := range in.ExportPolicy {
+			values = append(values, v)
+		}
+		out["export-policy"] = values
+	}
+	if in.PeerAddress != nil {
+		out["peer-address"] = *in.PeerAddress
+	}
+	if in.PeerAs != nil {
+		out["peer-as"] = *in.PeerAs
+	}
+	return out
+}
+
+// fromRFC7951 sets the BgpNeighbor from the RFC 7951 JSON object
+func (out *BgpNeighbor) fromRFC7951(in map[string]interface{}) error {
+	switch v, ok, err := rfc7951Member(in, "export-policy"); {
+	case err != nil:
+		return err
+	case ok:
+		values, err := rfc7951Array(v, "export-policy")
+		if err != nil {
+			return err
+		}
+		out.ExportPolicy = make([]string, 0, len(values))
+		for _, v := range values {
+			x, err := rfc7951String(v, "export-policy")
+			if err != nil {
+				return err
+			}
+			out.ExportPolicy = append(out.ExportPolicy, x)
+		}
+	}
+	switch v, ok, err := rfc7951Member(in, "peer-address"); {
+	case err != nil:
+		return err
+	case ok:
+		x, err := rfc7951String(v, "peer-address")
+		if err != nil {
+			return err
+		}
+		out.PeerAddress = &x
+	}
+	switch v, ok, err := rfc7951Member(in, "peer-as"); {
+	case err != nil:
+		return err
+	case ok:
+		i, err := rfc7951Uint(v, "peer-as", 32)
+		if err != nil {
+			return err
+		}
+		x := uint32(i)
+		out.PeerAs = &x
+	}
+	return nil
+}
+
+// rfc7951Decode decodes the RFC 7951 JSON object, the numbers are decoded as json.Number
+func rfc7951Decode(b []byte) (map[string]interface{}, error) {
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	var in map[string]interface{}
+	if err := d.Decode(&in); err != nil {
+		return nil, err
+	}
+	return in, nil
+}
+
+// rfc7951Member returns the value of the member of the JSON object, the name of the member
+// is matched with or without the module qualifier. A member that matches the name exactly
+// is returned, otherwise the name must match a single member of the JSON object.
+func rfc7951Member(in map[string]interface{}, name string) (interface{}, bool, error) {
+	if v, ok := in[name]; ok {
+		return v, true, nil
+	}
+	name = name[strings.Index(name, ":")+1:]
+	var matches []string
+	for k := range in {
+		if k[strings.Index(k, ":")+1:] == name {
+			matches = append(matches, k)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, false, nil
+	case 1:
+		return in[matches[0]], true, nil
+	}
+	sort.Strings(matches)
+	return nil, false, fmt.Errorf("%s: ambiguous member, matches %s", name, strings.Join(matches, ", "))
+}
+
+func rfc7951Object(v interface{}, name string) (map[string]interface{}, error) {
+	if o, ok := v.(map[string]interface{}); ok {
+		return o, nil
+	}
+	return nil, fmt.Errorf("%s: expected a JSON object, got %v", name, v)
+}
+
+func rfc7951Array(v interface{}, name string) ([]interface{}, error) {
+	if a, ok := v.([]interface{}); ok {
+		return a, nil
+	}
+	return nil, fmt.Errorf("%s: expected a JSON array, got %v", name, v)
+}
+
+func rfc7951String(v interface{}, name string) (string, error) {
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	return "", fmt.Errorf("%s: expected a JSON string, got %v", name, v)
+}
+
+func rfc7951Bool(v interface{}, name string) (bool, error) {
+	if b, ok := v.(bool); ok {
+		return b, nil
+	}
+	return false, fmt.Errorf("%s: expected a JSON boolean, got %v", name, v)
+}
+
+// rfc7951Number returns the number as a string, 64 bit integers are encoded as a JSON string
+func rfc7951Number(v interface{}, name string) (string, error) {
+	switch n := v.(type) {
+	case json.Number:
+		return n.String(), nil
+	case string:
+		return n, nil
+	}
+	return "", fmt.Errorf("%s: expected a JSON number, got %v", name, v)
+}
+
+func rfc7951Int(v interface{}, name string, bits int) (int64, error) {
+	s, err := rfc7951Number(v, name)
+	if err != nil {
+		return 0, err
+	}
+	i, err := strconv.ParseInt(s, 10, bits)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %v", name, err)
+	}
+	return i, nil
+}
+
+func rfc7951Uint(v interface{}, name string, bits int) (uint64, error) {
+	s, err := rfc7951Number(v, name)
+	if err != nil {
+		return 0, err
+	}
+	i, err := strconv.ParseUint(s, 10, bits)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %v", name, err)
+	}
+	return i, nil
+}
+
+// rfc7951Empty checks the value of a leaf of type empty, encoded as [null]
+func rfc7951Empty(v interface{}, name string) error {
+	if a, ok := v.([]interface{}); ok && len(a) == 1 && a[0] == nil {
+		return nil
+	}
+	return fmt.Errorf("%s: expected [null], got %v", name, v)
+}
+
+// rfc7951EncodeIdentity qualifies the identity with the name of the module of the identity
+func rfc7951EncodeIdentity(v string, identities map[string]string) string {
+	if module, ok := identities[v]; ok {
+		return module + ":" + v
+	}
+	return v
+}
+
+// rfc7951DecodeIdentity removes the name of the module of the identity
+func rfc7951DecodeIdentity(v string, identities map[string]string) string {
+	if i := strings.Index(v, ":"); i >= 0 {
+		if module, ok := identities[v[i+1:]]; ok && module == v[:i] {
+			return v[i+1:]
+		}
+	}
+	return v
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package controllers sets up the controllers of the sample.ndd.yndd.io v1alpha1 managed resources.
+package controllers
+
+import (
+	"context"
+
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/yndd/ndd-runtime/pkg/logging"
+	"github.com/yndd/ndd-runtime/pkg/resource"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	"github.com/yndd/sample/internal/controllers/interfacesinterface"
+	"github.com/yndd/sample/internal/controllers/interfacesinterfacesubinterface"
+	"github.com/yndd/sample/internal/controllers/networkinstancesnetworkinstance"
+	"github.com/yndd/sample/internal/controllers/networkinstancesnetworkinstanceprotocolsbgp"
+)
+
+// Setup adds the controllers of the managed resources to the manager, the gnmi
+// clients to the network nodes are created with newClient
+func Setup(mgr ctrl.Manager, o controller.Options, l logging.Logger, newClient func(ctx context.Context, mg resource.Managed) (gnmi.GNMIClient, error)) error {
+	if err := interfacesinterface.Setup(mgr, o, l, newClient); err != nil {
+		return err
+	}
+	if err := interfacesinterfacesubinterface.Setup(mgr, o, l, newClient); err != nil {
+		return err
+	}
+	if err := networkinstancesnetworkinstance.Setup(mgr, o, l, newClient); err != nil {
+		return err
+	}
+	if err := networkinstancesnetworkinstanceprotocolsbgp.Setup(mgr, o, l, newClient); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,343 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package interfacesinterface reconciles the SampleInterfacesInterface managed resources with the
+// configuration of the network nodes over gNMI.
+package interfacesinterface
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/openconfig/gnmi/proto/gnmi_ext"
+	"github.com/pkg/errors"
+	nddv1 "github.com/yndd/ndd-runtime/apis/common/v1"
+	"github.com/yndd/ndd-runtime/pkg/event"
+	"github.com/yndd/ndd-runtime/pkg/gext"
+	"github.com/yndd/ndd-runtime/pkg/logging"
+	"github.com/yndd/ndd-runtime/pkg/reconciler/managed"
+	"github.com/yndd/ndd-runtime/pkg/resource"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	v1alpha1 "github.com/yndd/sample/apis/v1alpha1"
+)
+
+const (
+	errNotResource     = "managed resource is not a SampleInterfacesInterface custom resource"
+	errNoResource      = "SampleInterfacesInterface custom resource has no InterfacesInterface data"
+	errNewClient       = "cannot create gnmi client"
+	errMarshal         = "cannot marshal SampleInterfacesInterface custom resource"
+	errUnmarshal       = "cannot unmarshal SampleInterfacesInterface resource of the network node"
+	errGetResource     = "cannot get SampleInterfacesInterface resource"
+	errCreateResource  = "cannot create SampleInterfacesInterface resource"
+	errUpdateResource  = "cannot update SampleInterfacesInterface resource"
+	errDeleteResource  = "cannot delete SampleInterfacesInterface resource"
+	errGetConfig       = "cannot get the configuration of the network node"
+	errGetResourceName = "cannot get the name of the resource"
+	errNoNetworkNode   = "managed resource has no network node reference"
+)
+
+// NewClientFn returns a gnmi client to the network node of the managed resource
+type NewClientFn func(ctx context.Context, mg resource.Managed) (gnmi.GNMIClient, error)
+
+// Setup adds a controller that reconciles SampleInterfacesInterface managed resources.
+// The custom resource must implement resource.Managed.
+func Setup(mgr ctrl.Manager, o controller.Options, l logging.Logger, newClient NewClientFn) error {
+	name := managed.ControllerName(v1alpha1.SampleInterfacesInterfaceGroupKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.SampleInterfacesInterfaceGroupVersionKind),
+		managed.WithExternalConnecter(&connector{log: l, newClient: newClient}),
+		managed.WithLogger(l.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o).
+		For(&v1alpha1.SampleInterfacesInterface{}).
+		Complete(r)
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	log       logging.Logger
+	newClient NewClientFn
+}
+
+// Connect produces an ExternalClient with a gnmi client to the network node of the managed resource
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.SampleInterfacesInterface); !ok {
+		return nil, errors.New(errNotResource)
+	}
+	nn := mg.GetNetworkNodeReference()
+	if nn == nil || nn.Name == "" {
+		return nil, errors.New(errNoNetworkNode)
+	}
+	client, err := c.newClient(ctx, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &external{client: client, target: []string{nn.Name}, log: c.log}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	client gnmi.GNMIClient
+	target []string // the network nodes the gnmi client is connected to
+	log    logging.Logger
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.SampleInterfacesInterface)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotResource)
+	}
+	path, err := getPath(cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	rsp, err := e.client.Get(ctx, &gnmi.GetRequest{
+		Path:     []*gnmi.Path{path},
+		Encoding: gnmi.Encoding_JSON_IETF,
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return managed.ExternalObservation{Ready: true}, nil
+		}
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetResource)
+	}
+	observed := getJSONValue(rsp)
+	if len(observed) == 0 {
+		return managed.ExternalObservation{Ready: true}, nil
+	}
+	observed, err = getObservedValue(observed)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	desired, err := getValue(cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	return managed.ExternalObservation{
+		Ready:            true,
+		ResourceExists:   true,
+		ResourceUpToDate: isJSONEqual(observed, desired),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.SampleInterfacesInterface)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotResource)
+	}
+	path, err := getPath(cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	value, err := getValue(cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if _, err := e.client.Set(ctx, &gnmi.SetRequest{
+		Update: []*gnmi.Update{{
+			Path: path,
+			Val:  &gnmi.TypedValue{Value: &gnmi.TypedValue_JsonIetfVal{JsonIetfVal: value}},
+		}},
+	}); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateResource)
+	}
+	return managed.ExternalCreation{}, nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed, obs managed.ExternalObservation) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.SampleInterfacesInterface)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotResource)
+	}
+	path, err := getPath(cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	value, err := getValue(cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if _, err := e.client.Set(ctx, &gnmi.SetRequest{
+		Replace: []*gnmi.Update{{
+			Path: path,
+			Val:  &gnmi.TypedValue{Value: &gnmi.TypedValue_JsonIetfVal{JsonIetfVal: value}},
+		}},
+	}); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateResource)
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.SampleInterfacesInterface)
+	if !ok {
+		return errors.New(errNotResource)
+	}
+	path, err := getPath(cr)
+	if err != nil {
+		return err
+	}
+	if _, err := e.client.Set(ctx, &gnmi.SetRequest{
+		Delete: []*gnmi.Path{path},
+	}); err != nil {
+		return errors.Wrap(err, errDeleteResource)
+	}
+	return nil
+}
+
+// GetTarget returns the targets of the external client, the gnmi client is connected
+// to the network node of the managed resource
+func (e *external) GetTarget() []string {
+	return e.target
+}
+
+// GetConfig returns the configuration of the network node
+func (e *external) GetConfig(ctx context.Context) ([]byte, error) {
+	rsp, err := e.client.Get(ctx, &gnmi.GetRequest{
+		Path:     []*gnmi.Path{{}},
+		Type:     gnmi.GetRequest_CONFIG,
+		Encoding: gnmi.Encoding_JSON_IETF,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, errGetConfig)
+	}
+	return getJSONValue(rsp), nil
+}
+
+// GetResourceName returns the name of the managed resource matching the path, the device
+// driver of the network node resolves the name with the getresourcename gnmi extension.
+// The name is empty when the path is not managed by a managed resource.
+func (e *external) GetResourceName(ctx context.Context, path []*gnmi.Path) (string, error) {
+	ext, err := (&gext.GEXT{Action: gext.GEXTActionGetResourceName}).String()
+	if err != nil {
+		return "", errors.Wrap(err, errGetResourceName)
+	}
+	extension := &gnmi_ext.Extension{
+		Ext: &gnmi_ext.Extension_RegisteredExt{
+			RegisteredExt: &gnmi_ext.RegisteredExtension{
+				Id:  gnmi_ext.ExtensionID_EID_EXPERIMENTAL,
+				Msg: []byte(ext),
+			},
+		},
+	}
+	rsp, err := e.client.Get(ctx, &gnmi.GetRequest{
+		Path:      path,
+		Encoding:  gnmi.Encoding_JSON,
+		Extension: []*gnmi_ext.Extension{extension},
+	})
+	if err != nil {
+		return "", errors.Wrap(err, errGetResourceName)
+	}
+	b := getJSONValue(rsp)
+	if len(b) == 0 {
+		return "", nil
+	}
+	name := &nddv1.ResourceName{}
+	if err := json.Unmarshal(b, name); err != nil {
+		return "", errors.Wrap(err, errGetResourceName)
+	}
+	return name.Name, nil
+}
+
+// getPath returns the gnmi path of the resource with the keys of the custom resource
+func getPath(cr *v1alpha1.SampleInterfacesInterface) (*gnmi.Path, error) {
+	p := cr.Spec.ForNetworkNode
+	if p.SampleInterfacesInterface == nil {
+		return nil, errors.New(errNoResource)
+	}
+	return &gnmi.Path{
+		Elem: []*gnmi.PathElem{
+			{Name: "interfaces"},
+			{Name: "interface", Key: map[string]string{
+				"name": toKeyValue(p.SampleInterfacesInterface.Name),
+			}},
+		},
+	}, nil
+}
+
+// getValue returns the RFC 7951 JSON value of the resource
+func getValue(cr *v1alpha1.SampleInterfacesInterface) ([]byte, error) {
+	p := cr.Spec.ForNetworkNode
+	if p.SampleInterfacesInterface == nil {
+		return nil, errors.New(errNoResource)
+	}
+	b, err := p.MarshalRFC7951()
+	if err != nil {
+		return nil, errors.Wrap(err, errMarshal)
+	}
+	return b, nil
+}
+
+// getObservedValue returns the RFC 7951 JSON value of the resource on the network node
+// without the data that is not part of the custom resource
+func getObservedValue(b []byte) ([]byte, error) {
+	p := &v1alpha1.SampleInterfacesInterfaceParameters{}
+	if err := p.UnmarshalRFC7951(b); err != nil {
+		return nil, errors.Wrap(err, errUnmarshal)
+	}
+	return p.MarshalRFC7951()
+}
+
+// toKeyValue returns the value of a key of the gnmi path, empty when the key is not set
+func toKeyValue(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() || rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return ""
+	}
+	return fmt.Sprintf("%v", reflect.Indirect(rv).Interface())
+}
+
+// getJSONValue returns the json value of the first update of the get response
+func getJSONValue(rsp *gnmi.GetResponse) []byte {
+	for _, n := range rsp.GetNotification() {
+		for _, u := range n.GetUpdate() {
+			if b := u.GetVal().GetJsonIetfVal(); len(b) > 0 {
+				return b
+			}
+			if b := u.GetVal().GetJsonVal(); len(b) > 0 {
+				return b
+			}
+		}
+	}
+	return nil
+}
+
+// isJSONEqual returns true if the json values are semantically equal
+func isJSONEqual(a, b []byte) bool {
+	var x, y interface{}
+	if err := json.Unmarshal(a, &x); err != nil {
+		return bytes.Equal(a, b)
+	}
+	if err := json.Unmarshal(b, &y); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(x, y)
+}
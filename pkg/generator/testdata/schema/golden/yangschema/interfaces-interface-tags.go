@@ -0,0 +1,31 @@
+package yangschema
+
+import (
+	"github.com/yndd/ndd-yang/pkg/leafref"
+	"github.com/yndd/ndd-yang/pkg/yentry"
+)
+
+func initInterfacesInterfaceTags(p *yentry.Entry, opts ...yentry.EntryOption) *yentry.Entry {
+	children := map[string]yentry.EntryInitFunc{}
+	e := &yentry.Entry{
+		Name:             "tags",
+		Key:              []string{},
+		Module:           "",
+		Namespace:        "",
+		Prefix:           "sif",
+		Parent:           p,
+		Children:         make(map[string]*yentry.Entry),
+		ResourceBoundary: false,
+		LeafRefs:         []*leafref.LeafRef{},
+		Defaults:         map[string]string{},
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	for name, initFunc := range children {
+		e.Children[name] = initFunc(e, yentry.WithLogging(e.Log))
+	}
+	return e
+}
@@ -0,0 +1,38 @@
+package yangschema
+
+import (
+	"github.com/yndd/ndd-yang/pkg/leafref"
+	"github.com/yndd/ndd-yang/pkg/yentry"
+)
+
+func initInterfacesInterface(p *yentry.Entry, opts ...yentry.EntryOption) *yentry.Entry {
+	children := map[string]yentry.EntryInitFunc{
+		"subinterface": initInterfacesInterfaceSubinterface,
+		"tags":         initInterfacesInterfaceTags,
+	}
+	e := &yentry.Entry{
+		Name: "interface",
+		Key: []string{
+			"name",
+		},
+		Module:           "",
+		Namespace:        "",
+		Prefix:           "sif",
+		Parent:           p,
+		Children:         make(map[string]*yentry.Entry),
+		ResourceBoundary: true,
+		LeafRefs:         []*leafref.LeafRef{},
+		Defaults: map[string]string{
+			"admin-state": "enable",
+		},
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	for name, initFunc := range children {
+		e.Children[name] = initFunc(e, yentry.WithLogging(e.Log))
+	}
+	return e
+}
@@ -0,0 +1,63 @@
+package yangschema
+
+import (
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/yndd/ndd-yang/pkg/leafref"
+	"github.com/yndd/ndd-yang/pkg/yentry"
+)
+
+func initNetworkinstancesNetworkinstanceInterface(p *yentry.Entry, opts ...yentry.EntryOption) *yentry.Entry {
+	children := map[string]yentry.EntryInitFunc{}
+	e := &yentry.Entry{
+		Name: "interface",
+		Key: []string{
+			"name",
+			"subinterface",
+		},
+		Module:           "",
+		Namespace:        "",
+		Prefix:           "sni",
+		Parent:           p,
+		Children:         make(map[string]*yentry.Entry),
+		ResourceBoundary: false,
+		LeafRefs: []*leafref.LeafRef{
+			{
+				LocalPath: &gnmi.Path{
+					Elem: []*gnmi.PathElem{
+						{Name: "name"},
+					},
+				},
+				RemotePath: &gnmi.Path{
+					Elem: []*gnmi.PathElem{
+						{Name: "interfaces"},
+						{Name: "interface", Key: map[string]string{"name": ""}},
+					},
+				},
+			},
+			{
+				LocalPath: &gnmi.Path{
+					Elem: []*gnmi.PathElem{
+						{Name: "subinterface"},
+					},
+				},
+				RemotePath: &gnmi.Path{
+					Elem: []*gnmi.PathElem{
+						{Name: "interfaces"},
+						{Name: "interface"},
+						{Name: "subinterface", Key: map[string]string{"index": ""}},
+					},
+				},
+			},
+		},
+		Defaults: map[string]string{},
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	for name, initFunc := range children {
+		e.Children[name] = initFunc(e, yentry.WithLogging(e.Log))
+	}
+	return e
+}
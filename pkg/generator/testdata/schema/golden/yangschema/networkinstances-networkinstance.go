@@ -0,0 +1,54 @@
+package yangschema
+
+import (
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/yndd/ndd-yang/pkg/leafref"
+	"github.com/yndd/ndd-yang/pkg/yentry"
+)
+
+func initNetworkinstancesNetworkinstance(p *yentry.Entry, opts ...yentry.EntryOption) *yentry.Entry {
+	children := map[string]yentry.EntryInitFunc{
+		"interface": initNetworkinstancesNetworkinstanceInterface,
+		"protocols": initNetworkinstancesNetworkinstanceProtocols,
+	}
+	e := &yentry.Entry{
+		Name: "network-instance",
+		Key: []string{
+			"name",
+		},
+		Module:           "",
+		Namespace:        "",
+		Prefix:           "sni",
+		Parent:           p,
+		Children:         make(map[string]*yentry.Entry),
+		ResourceBoundary: false,
+		LeafRefs: []*leafref.LeafRef{
+			{
+				LocalPath: &gnmi.Path{
+					Elem: []*gnmi.PathElem{
+						{Name: "default-interface"},
+					},
+				},
+				RemotePath: &gnmi.Path{
+					Elem: []*gnmi.PathElem{
+						{Name: "network-instances"},
+						{Name: "network-instance"},
+						{Name: "interface", Key: map[string]string{"name": ""}},
+					},
+				},
+			},
+		},
+		Defaults: map[string]string{
+			"type": "default",
+		},
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	for name, initFunc := range children {
+		e.Children[name] = initFunc(e, yentry.WithLogging(e.Log))
+	}
+	return e
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/yndd/ndd-yang/pkg/resource"
+)
+
+// resourceTrie is a prefix trie on the pathElem names of the absolute resource paths
+// and the absolute exclude paths of the resources. It allows to lookup the resource
+// of a path in the order of the length of the path, iso the number of resources.
+type resourceTrie struct {
+	root *resourceTrieNode
+}
+
+type resourceTrieNode struct {
+	children map[string]*resourceTrieNode
+	// the resource with an absolute path that ends at this node
+	resource *resource.Resource
+	// the resources with an exclude path that ends at this node
+	excludedBy map[*resource.Resource]bool
+}
+
+func newResourceTrieNode() *resourceTrieNode {
+	return &resourceTrieNode{
+		children: make(map[string]*resourceTrieNode),
+	}
+}
+
+// newResourceTrie builds the trie for the supplied resources, when multiple resources
+// have the same path the first resource is used.
+func newResourceTrie(resources []*resource.Resource) *resourceTrie {
+	t := &resourceTrie{
+		root: newResourceTrieNode(),
+	}
+	for _, r := range resources {
+		n := t.insert(r.GetAbsolutePath())
+		if n.resource == nil {
+			n.resource = r
+		}
+		for _, excl := range getAbsoluteExcludePaths(r) {
+			n := t.insert(excl)
+			if n.excludedBy == nil {
+				n.excludedBy = make(map[*resource.Resource]bool)
+			}
+			n.excludedBy[r] = true
+		}
+	}
+	return t
+}
+
+// newResourceSourceTrie builds the trie on the resource paths without the module as
+// first element, as is the case for leafref paths. The excludes are not indexed.
+func newResourceSourceTrie(resources []*resource.Resource) *resourceTrie {
+	t := &resourceTrie{
+		root: newResourceTrieNode(),
+	}
+	for _, r := range resources {
+		p := r.GetAbsoluteGnmiPathFromSource()
+		if len(p.GetElem()) == 0 {
+			continue
+		}
+		if n := t.insert(p); n.resource == nil {
+			n.resource = r
+		}
+	}
+	return t
+}
+
+// insert returns the node of the path and creates the nodes along the path if they dont exist
+func (t *resourceTrie) insert(p *gnmi.Path) *resourceTrieNode {
+	n := t.root
+	for _, pe := range p.GetElem() {
+		child, ok := n.children[pe.GetName()]
+		if !ok {
+			child = newResourceTrieNode()
+			n.children[pe.GetName()] = child
+		}
+		n = child
+	}
+	return n
+}
+
+// lookup returns the nodes along the path, starting with the root node
+func (t *resourceTrie) lookup(p *gnmi.Path) []*resourceTrieNode {
+	nodes := make([]*resourceTrieNode, 0, len(p.GetElem())+1)
+	n := t.root
+	nodes = append(nodes, n)
+	for _, pe := range p.GetElem() {
+		child, ok := n.children[pe.GetName()]
+		if !ok {
+			break
+		}
+		n = child
+		nodes = append(nodes, n)
+	}
+	return nodes
+}
+
+// findBestMatch returns the resource with the longest path that is a prefix of the path
+// and indicates if the path is excluded from that resource
+func (t *resourceTrie) findBestMatch(p *gnmi.Path) (*resource.Resource, bool, bool) {
+	nodes := t.lookup(p)
+	var r *resource.Resource
+	for i := len(nodes) - 1; i >= 0; i-- {
+		if nodes[i].resource != nil {
+			r = nodes[i].resource
+			break
+		}
+	}
+	if r == nil {
+		return nil, false, false
+	}
+	for _, n := range nodes {
+		if n.excludedBy[r] {
+			return r, true, true
+		}
+	}
+	return r, false, true
+}
+
+// isResourceBoundary returns true if a resource path matches the path exactly
+func (t *resourceTrie) isResourceBoundary(p *gnmi.Path) bool {
+	nodes := t.lookup(p)
+	return len(nodes) == len(p.GetElem())+1 && nodes[len(nodes)-1].resource != nil
+}
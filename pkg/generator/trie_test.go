@@ -0,0 +1,227 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/yndd/ndd-runtime/pkg/logging"
+	"github.com/yndd/ndd-yang/pkg/resource"
+	"github.com/yndd/ndd-yang/pkg/yparser"
+)
+
+// newBenchmarkResources returns a resource tree with the size of a large vendor model,
+// n top level resources with each a hierarchical child resource and an exclude, together
+// with the paths of the yang nodes that are visited when generating the resources
+func newBenchmarkResources(n int) ([]*resource.Resource, []*gnmi.Path) {
+	root := resource.NewResource(nil)
+	resources := []*resource.Resource{root}
+	paths := make([]*gnmi.Path, 0)
+	for i := 0; i < n; i++ {
+		p := fmt.Sprintf("/module-%d/container-%d/list-%d", i%10, i, i)
+		r := resource.NewResource(root,
+			resource.WithXPath(p),
+			resource.WithModule(fmt.Sprintf("module-%d", i%10)),
+			resource.WithExclude(p+"/child"))
+		root.AddChild(r)
+		child := resource.NewResource(r,
+			resource.WithXPath("/child"),
+			resource.WithModule(r.GetModule()),
+			resource.WithExclude("/child/excluded"))
+		r.AddChild(child)
+		resources = append(resources, r, child)
+
+		for j := 0; j < 10; j++ {
+			paths = append(paths,
+				yparser.Xpath2GnmiPath(fmt.Sprintf("%s/leaf-%d", p, j), 0),
+				yparser.Xpath2GnmiPath(fmt.Sprintf("%s/child/container/leaf-%d", p, j), 0),
+				yparser.Xpath2GnmiPath(fmt.Sprintf("%s/child/excluded/leaf-%d", p, j), 0),
+			)
+		}
+	}
+	return resources, paths
+}
+
+// findBestMatchLinear is the linear scan over all resources the trie replaces
+func findBestMatchLinear(resources []*resource.Resource, inputPath *gnmi.Path) (*resource.Resource, bool) {
+	minLength := 0
+	var resMatch *resource.Resource
+	for _, r := range resources {
+		if len(r.GetAbsolutePath().GetElem()) <= len(inputPath.GetElem()) {
+			found := true
+			for i, pathElem := range r.GetAbsolutePath().GetElem() {
+				if pathElem.GetName() != inputPath.GetElem()[i].GetName() {
+					found = false
+					break
+				}
+			}
+			if found && len(r.GetAbsolutePath().GetElem()) > minLength {
+				resMatch = r
+				minLength = len(r.GetAbsolutePath().GetElem())
+			}
+		}
+	}
+	return resMatch, resMatch != nil
+}
+
+func TestResourceTrieFindBestMatch(t *testing.T) {
+	resources, paths := newBenchmarkResources(10)
+	trie := newResourceTrie(resources[1:])
+	for _, p := range paths {
+		want, wantOk := findBestMatchLinear(resources[1:], p)
+		got, _, gotOk := trie.findBestMatch(p)
+		if got != want || gotOk != wantOk {
+			t.Errorf("findBestMatch(%s): got %v, want %v", yparser.GnmiPath2XPath(p, false), got, want)
+		}
+	}
+}
+
+func TestResourceTrieExcluded(t *testing.T) {
+	resources, _ := newBenchmarkResources(1)
+	trie := newResourceTrie(resources[1:])
+	cases := map[string]bool{
+		"/module-0/container-0/list-0/leaf":                  false,
+		"/module-0/container-0/list-0/child/leaf":            false,
+		"/module-0/container-0/list-0/child/excluded":        true,
+		"/module-0/container-0/list-0/child/excluded/leaf-0": true,
+	}
+	for p, want := range cases {
+		if _, got, _ := trie.findBestMatch(yparser.Xpath2GnmiPath(p, 0)); got != want {
+			t.Errorf("excluded(%s): got %t, want %t", p, got, want)
+		}
+	}
+	if !trie.isResourceBoundary(yparser.Xpath2GnmiPath("/module-0/container-0/list-0/child", 0)) {
+		t.Errorf("expected a resource boundary")
+	}
+	if trie.isResourceBoundary(yparser.Xpath2GnmiPath("/module-0/container-0", 0)) {
+		t.Errorf("expected no resource boundary")
+	}
+}
+
+func TestResourceSourceTrieFindBestMatch(t *testing.T) {
+	resources, paths := newBenchmarkResources(10)
+	trie := newResourceSourceTrie(resources[1:])
+	for _, p := range paths {
+		// the paths of leafrefs do not start with the module
+		sp := &gnmi.Path{Elem: p.GetElem()[1:]}
+		want, wantOk := findBestMatchLinear(resources[1:], p)
+		got, excluded, gotOk := trie.findBestMatch(sp)
+		if got != want || gotOk != wantOk || excluded {
+			t.Errorf("findBestMatch(%s): got %v, want %v", yparser.GnmiPath2XPath(sp, false), got, want)
+		}
+	}
+}
+
+func BenchmarkFindBestMatchLinear(b *testing.B) {
+	for _, n := range []int{100, 500} {
+		resources, paths := newBenchmarkResources(n)
+		b.Run(fmt.Sprintf("resources-%d", len(resources)-1), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for _, p := range paths {
+					findBestMatchLinear(resources[1:], p)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkFindBestMatchTrie(b *testing.B) {
+	for _, n := range []int{100, 500} {
+		resources, paths := newBenchmarkResources(n)
+		trie := newResourceTrie(resources[1:])
+		b.Run(fmt.Sprintf("resources-%d", len(resources)-1), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for _, p := range paths {
+					trie.findBestMatch(p)
+				}
+			}
+		})
+	}
+}
+
+// writeBenchmarkModel writes a yang module with n top level containers, each with a list that
+// is mapped as a resource with an excluded container, and the resource map of the lists
+func writeBenchmarkModel(b *testing.B, dir string, n int) {
+	yb := strings.Builder{}
+	mb := strings.Builder{}
+	yb.WriteString("module bench {\n  namespace \"urn:bench\";\n  prefix b;\n")
+	mb.WriteString("path:\n")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&yb, "  container container-%d {\n    list list-%d {\n      key \"name\";\n", i, i)
+		yb.WriteString("      leaf name {\n        type string;\n      }\n")
+		for j := 0; j < 10; j++ {
+			fmt.Fprintf(&yb, "      leaf leaf-%d {\n        type uint32;\n      }\n", j)
+		}
+		for _, c := range []string{"config", "state"} {
+			fmt.Fprintf(&yb, "      container %s-%d {\n", c, i)
+			for j := 0; j < 5; j++ {
+				fmt.Fprintf(&yb, "        leaf leaf-%d {\n          type string;\n        }\n", j)
+			}
+			yb.WriteString("      }\n")
+		}
+		yb.WriteString("    }\n  }\n")
+		fmt.Fprintf(&mb, "  /bench/container-%d/list-%d:\n    excludes:\n      - /state-%d\n", i, i, i)
+	}
+	yb.WriteString("}\n")
+
+	if err := os.MkdirAll(filepath.Join(dir, "yang"), 0755); err != nil {
+		b.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "yang", "bench.yang"), []byte(yb.String()), 0644); err != nil {
+		b.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "map.yaml"), []byte(mb.String()), 0644); err != nil {
+		b.Fatal(err)
+	}
+}
+
+// BenchmarkGenerate measures the generation of the api types of a large model, from reading
+// the yang modules to rendering the resources
+func BenchmarkGenerate(b *testing.B) {
+	for _, n := range []int{100, 500} {
+		dir := b.TempDir()
+		writeBenchmarkModel(b, dir, n)
+		b.Run(fmt.Sprintf("resources-%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				g, err := NewGenerator(
+					WithLogging(logging.NewNopLogger()),
+					WithYangModuleDirs([]string{filepath.Join(dir, "yang")}),
+					WithResourceMapInputFile(filepath.Join(dir, "map.yaml")),
+					WithOutputDir(filepath.Join(dir, "out")),
+					WithVersion("v1alpha1"),
+					WithAPIGroup("bench.ndd.yndd.io"),
+					WithPrefix("bench"),
+					WithLocalRender(true),
+				)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if err := g.Run(); err != nil {
+					b.Fatal(err)
+				}
+				if err := g.Render(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"sort"
+
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/pkg/errors"
+	"github.com/yndd/ndd-yang/pkg/resource"
+	"github.com/yndd/ndd-yang/pkg/yparser"
+)
+
+const (
+	errUnknownNode             = "unknown node"
+	errResourcePathInvalid     = "invalid resource path"
+	errExcludePathInvalid      = "invalid exclude path"
+	errStaticLeafRefLocal      = "invalid static-leafref local path"
+	errStaticLeafRefRemote     = "dangling static-leafref target"
+	errResourceDuplicate       = "duplicate resource"
+	errResourceOverlap         = "resource overlaps with resource"
+	errResourceOverlapHint     = "declare it in its hierarchy"
+	errResourcePathEmpty       = "empty resource path"
+	maxSuggestionDistanceRatio = 3
+)
+
+// Validate validates the resource map against the yang modules. It resolves the resource
+// paths, the exclude paths and the static leafrefs and reports overlapping resources.
+// All problems are returned as Errors
+func (g *Generator) Validate() error {
+	var errs Errors
+
+	resources := g.GetResources()[1:]
+	for _, r := range resources {
+		absPath := r.GetAbsolutePath()
+		if len(absPath.GetElem()) == 0 {
+			errs = appendError(errs, errors.New(errResourcePathEmpty))
+			continue
+		}
+		if _, err := g.resolvePath(absPath); err != nil {
+			errs = appendError(errs, errors.Wrap(err, errResourcePathInvalid))
+			// the excludes cannot be resolved when the resource path is invalid
+			continue
+		}
+		for _, excl := range getAbsoluteExcludePaths(r) {
+			if _, err := g.resolvePath(excl); err != nil {
+				errs = appendError(errs, errors.Wrap(err, errExcludePathInvalid))
+			}
+		}
+	}
+
+	errs = appendError(errs, validateResourceOverlap(resources))
+
+	// sort the static leafrefs to provide a stable output
+	localPaths := make([]string, 0, len(g.staticLeafRef))
+	for localPath := range g.staticLeafRef {
+		localPaths = append(localPaths, localPath)
+	}
+	sort.Strings(localPaths)
+	for _, localPath := range localPaths {
+		if _, err := g.resolvePath(yparser.Xpath2GnmiPath(localPath, 0)); err != nil {
+			errs = appendError(errs, errors.Wrap(err, errStaticLeafRefLocal))
+		}
+		remotePath := g.staticLeafRef[localPath]
+		if _, err := g.resolvePath(yparser.Xpath2GnmiPath(remotePath, 0)); err != nil {
+			errs = appendError(errs, errors.Wrapf(err, "%s from %s", errStaticLeafRefRemote, localPath))
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+// getAbsoluteExcludePaths returns the excludes of the resource as absolute paths,
+// the excludes of a hierarchical resource are relative to the path of its parent
+func getAbsoluteExcludePaths(r *resource.Resource) []*gnmi.Path {
+	paths := make([]*gnmi.Path, 0, len(r.Excludes))
+	for _, excl := range r.Excludes {
+		p := &gnmi.Path{Elem: make([]*gnmi.PathElem, 0)}
+		if r.GetParent() != nil && r.GetParent().GetParent() != nil {
+			p.Elem = append(p.Elem, r.GetParent().GetAbsolutePath().GetElem()...)
+		}
+		p.Elem = append(p.Elem, excl.GetElem()...)
+		paths = append(paths, p)
+	}
+	return paths
+}
+
+// validateResourceOverlap reports resources with the same path and resources that are
+// nested in another resource without being declared in its hierarchy
+func validateResourceOverlap(resources []*resource.Resource) error {
+	var errs Errors
+	for i, r1 := range resources {
+		p1 := yparser.GnmiPath2XPath(r1.GetAbsolutePath(), false)
+		for _, r2 := range resources[i+1:] {
+			p2 := yparser.GnmiPath2XPath(r2.GetAbsolutePath(), false)
+			switch {
+			case p1 == p2:
+				errs = appendError(errs, errors.Errorf("%s: %s", errResourceDuplicate, p1))
+			case isPathPrefix(r1.GetAbsolutePath(), r2.GetAbsolutePath()) && !isAncestor(r1, r2):
+				errs = appendError(errs, errors.Errorf("%s: %s %s, %s", p2, errResourceOverlap, p1, errResourceOverlapHint))
+			case isPathPrefix(r2.GetAbsolutePath(), r1.GetAbsolutePath()) && !isAncestor(r2, r1):
+				errs = appendError(errs, errors.Errorf("%s: %s %s, %s", p1, errResourceOverlap, p2, errResourceOverlapHint))
+			}
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+// isPathPrefix returns true if the elements of p are a strict prefix of the elements of q
+func isPathPrefix(p, q *gnmi.Path) bool {
+	if len(p.GetElem()) >= len(q.GetElem()) {
+		return false
+	}
+	for i, pe := range p.GetElem() {
+		if pe.GetName() != q.GetElem()[i].GetName() {
+			return false
+		}
+	}
+	return true
+}
+
+// isAncestor returns true if a is a parent resource of r in the resource hierarchy
+func isAncestor(a, r *resource.Resource) bool {
+	for p := r.GetParent(); p != nil; p = p.GetParent() {
+		if p == a {
+			return true
+		}
+	}
+	return false
+}
+
+// resolvePath resolves the path against the yang tree. The first element of the path is
+// the module name; when it does not match a module the path is resolved against the top
+// level nodes of all modules, as is the case for leafref paths.
+func (g *Generator) resolvePath(p *gnmi.Path) (*yang.Entry, error) {
+	elems := p.GetElem()
+	if len(elems) == 0 {
+		return nil, errors.New(errResourcePathEmpty)
+	}
+	modules := g.getEntries()
+	for _, m := range modules {
+		if m.Name == elems[0].GetName() {
+			return resolveEntry(m, elems[1:], "/"+m.Name)
+		}
+	}
+	// unqualified path, resolve the path in the module that contains the first element
+	for _, m := range modules {
+		if getChildEntry(m, elems[0].GetName()) != nil {
+			return resolveEntry(m, elems, "")
+		}
+	}
+	candidates := make([]string, 0, len(modules))
+	for _, m := range modules {
+		candidates = append(candidates, m.Name)
+		candidates = append(candidates, getChildEntryNames(m)...)
+	}
+	return nil, unknownNodeError(yparser.GnmiPath2XPath(p, false), elems[0].GetName(), candidates)
+}
+
+// resolveEntry walks the path elements from the entry, choice and case nodes are
+// transparent in the path as is the case in the ResourceGenerator
+func resolveEntry(e *yang.Entry, elems []*gnmi.PathElem, resolved string) (*yang.Entry, error) {
+	for _, pe := range elems {
+		child := getChildEntry(e, pe.GetName())
+		if child == nil {
+			return nil, unknownNodeError(resolved+"/"+pe.GetName(), pe.GetName(), getChildEntryNames(e))
+		}
+		resolved += "/" + pe.GetName()
+		e = child
+	}
+	return e, nil
+}
+
+// getChildEntry returns the child entry with the name, looking through choice and case nodes
+func getChildEntry(e *yang.Entry, name string) *yang.Entry {
+	for _, child := range e.Dir {
+		if child.IsChoice() || child.IsCase() {
+			if ce := getChildEntry(child, name); ce != nil {
+				return ce
+			}
+			continue
+		}
+		if child.Name == name {
+			return child
+		}
+	}
+	return nil
+}
+
+// getChildEntryNames returns the names of the child entries, looking through choice and case nodes
+func getChildEntryNames(e *yang.Entry) []string {
+	names := make([]string, 0, len(e.Dir))
+	for _, child := range e.Dir {
+		if child.IsChoice() || child.IsCase() {
+			names = append(names, getChildEntryNames(child)...)
+			continue
+		}
+		names = append(names, child.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func unknownNodeError(path, name string, candidates []string) error {
+	if s := didYouMean(name, candidates); s != "" {
+		return errors.Errorf("%s: %s %q, did you mean %q?", path, errUnknownNode, name, s)
+	}
+	return errors.Errorf("%s: %s %q", path, errUnknownNode, name)
+}
+
+// didYouMean returns the candidate closest to the name, when it is close enough
+func didYouMean(name string, candidates []string) string {
+	best := ""
+	bestDistance := len(name)/maxSuggestionDistanceRatio + 1
+	for _, c := range candidates {
+		if d := levenshtein(name, c); d <= bestDistance && (best == "" || d < levenshtein(name, best)) {
+			best = c
+			bestDistance = d
+		}
+	}
+	return best
+}
+
+// levenshtein returns the edit distance between two strings
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	m := a
+	if b < m {
+		m = b
+	}
+	if c < m {
+		m = c
+	}
+	return m
+}
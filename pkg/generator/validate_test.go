@@ -0,0 +1,189 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yndd/ndd-runtime/pkg/logging"
+	"github.com/yndd/ndd-ygen/pkg/fsys"
+)
+
+func TestValidate(t *testing.T) {
+	cases := map[string]struct {
+		resourceMap string
+		want        []string
+	}{
+		"Valid": {
+			resourceMap: `path:
+  /test-system/system/server:
+    excludes:
+      - /timers
+  /test-choice/routes/route:
+    hierarchy:
+      /label:
+static-leafref:
+  /test-choice/routes/route/interface: /test-system/system/server/name
+`,
+		},
+		"ChoiceIsTransparent": {
+			resourceMap: "path:\n  /test-choice/routes/route/address:\n",
+		},
+		"UnqualifiedLeafRef": {
+			resourceMap: "static-leafref:\n  /routes/route/interface: /system/server/name\n",
+		},
+		"UnknownModule": {
+			resourceMap: "path:\n  /test-sytem/system/server:\n",
+			want: []string{
+				`invalid resource path: /test-sytem/system/server: unknown node "test-sytem", did you mean "test-system"?`,
+			},
+		},
+		"UnknownNode": {
+			resourceMap: "path:\n  /test-system/system/servers:\n",
+			want: []string{
+				`invalid resource path: /test-system/system/servers: unknown node "servers", did you mean "server"?`,
+			},
+		},
+		"UnknownNodeWithoutSuggestion": {
+			resourceMap: "path:\n  /test-system/system/client:\n",
+			want: []string{
+				`invalid resource path: /test-system/system/client: unknown node "client"`,
+			},
+		},
+		"InvalidExclude": {
+			resourceMap: "path:\n  /test-system/system/server:\n    excludes:\n      - /timer\n",
+			want: []string{
+				`invalid exclude path: /test-system/system/server/timer: unknown node "timer", did you mean "timers"?`,
+			},
+		},
+		"InvalidHierarchyExclude": {
+			resourceMap: "path:\n  /test-choice/routes/route:\n    hierarchy:\n      /label:\n        excludes:\n          - /values\n",
+			want: []string{
+				`invalid exclude path: /test-choice/routes/route/label/values: unknown node "values", did you mean "value"?`,
+			},
+		},
+		"Overlap": {
+			resourceMap: "path:\n  /test-system/system/server:\n  /test-system/system/server/timers:\n",
+			want: []string{
+				"/test-system/system/server/timers: resource overlaps with resource /test-system/system/server, declare it in its hierarchy",
+			},
+		},
+		"HierarchyDoesNotOverlap": {
+			resourceMap: "path:\n  /test-system/system/server:\n    hierarchy:\n      /timers:\n",
+		},
+		"DanglingLeafRef": {
+			resourceMap: "static-leafref:\n  /test-choice/routes/route/interface: /test-system/system/server/nam\n",
+			want: []string{
+				`dangling static-leafref target from /test-choice/routes/route/interface: /test-system/system/server/nam: unknown node "nam", did you mean "name"?`,
+			},
+		},
+		"InvalidLeafRef": {
+			resourceMap: "static-leafref:\n  /test-choice/routes/route/interfaces: /test-system/system/server/name\n",
+			want: []string{
+				`invalid static-leafref local path: /test-choice/routes/route/interfaces: unknown node "interfaces", did you mean "interface"?`,
+			},
+		},
+		"AllErrors": {
+			resourceMap: "path:\n  /test-system/system/servers:\n  /test-choice/route:\n",
+			want: []string{
+				`invalid resource path: /test-choice/route: unknown node "route", did you mean "routes"?`,
+				`invalid resource path: /test-system/system/servers: unknown node "servers", did you mean "server"?`,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			inputFS := fsys.NewMemFS()
+			for name, data := range map[string]string{
+				"yang/test-system.yang":     testSystemYang,
+				"yang/test-system-ext.yang": testSystemExtYang,
+				"yang/test-choice.yang":     testChoiceYang,
+				"map.yaml":                  tc.resourceMap,
+			} {
+				if err := inputFS.WriteFile(name, []byte(data), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			g, err := NewGenerator(
+				WithInputFS(inputFS),
+				WithOutputFS(fsys.NewMemFS()),
+				WithLogging(logging.NewNopLogger()),
+				WithYangModuleDirs([]string{"yang"}),
+				WithResourceMapInputFile("map.yaml"),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			if err := g.Validate(); err != nil {
+				errs, ok := err.(Errors)
+				if !ok {
+					t.Fatalf("Validate: got %v, want Errors", err)
+				}
+				for _, err := range errs {
+					got = append(got, err.Error())
+				}
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Validate:\ngot:  %q\nwant: %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDidYouMean(t *testing.T) {
+	candidates := []string{"interface", "interfaces", "network-instance", "mtu"}
+	cases := map[string]struct {
+		name string
+		want string
+	}{
+		"Exact":     {name: "interface", want: "interface"},
+		"Closest":   {name: "interfaes", want: "interfaces"},
+		"Tie":       {name: "interfacs", want: "interface"},
+		"Typo":      {name: "network-instnce", want: "network-instance"},
+		"Short":     {name: "mtv", want: "mtu"},
+		"TooFar":    {name: "vlan", want: ""},
+		"Unrelated": {name: "protocols", want: ""},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := didYouMean(tc.name, candidates); got != tc.want {
+				t.Errorf("didYouMean(%q): got %q, want %q", tc.name, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{a: "", b: "", want: 0},
+		{a: "abc", b: "", want: 3},
+		{a: "", b: "abc", want: 3},
+		{a: "kitten", b: "sitting", want: 3},
+		{a: "server", b: "servers", want: 1},
+		{a: "route", b: "routes", want: 1},
+	}
+	for _, tc := range cases {
+		if got := levenshtein(tc.a, tc.b); got != tc.want {
+			t.Errorf("levenshtein(%q, %q): got %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
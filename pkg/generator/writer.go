@@ -17,121 +17,180 @@ limitations under the License.
 package generator
 
 import (
+	"bytes"
 	"fmt"
-	"os"
+	"io"
 	"path/filepath"
 
+	"github.com/pkg/errors"
+	"github.com/stoewer/go-strcase"
 	"github.com/yndd/ndd-yang/pkg/container"
 	"github.com/yndd/ndd-yang/pkg/leafref"
+	"github.com/yndd/ndd-yang/pkg/resource"
 	"github.com/yndd/ndd-yang/pkg/yparser"
 )
 
-func renderContainers(c *container.Container) {
-	if c != nil {
+const (
+	// groupVersionInfoFileName is the name of the file with the group version of the api package
+	groupVersionInfoFileName = "groupversion_info.go"
+	// managedFileName is the name of the file with the resource.Managed methods of the resources
+	managedFileName = "zz_generated.managed.go"
 
-		fmt.Printf("container info: %v\n", c)
-		fmt.Printf("getContainerList name: %s\n", c.GetFullNameWithRoot())
-
-		for _, e := range c.GetEntries() {
-			fmt.Printf("  entry Name: %s, Type: %s\n", e.Name, e.Type)
-		}
+	errDuplicateTypeName = "multiple resources render the same go type"
+)
 
-		for _, c := range c.GetChildren() {
-			renderContainers(c)
-		}
+// Render writes the kubernetes api types of the resources, the methods that implement
+// resource.Managed and the group version info of the api package
+func (g *Generator) Render() error {
+	g.startRender(renderKindResources)
+	if err := g.checkTypeNames(); err != nil {
+		return err
 	}
-
+	jobs := make([]func() error, 0)
+	for _, r := range g.getRenderResources() {
+		r := r
+		jobs = append(jobs, func() error {
+			return g.renderResource(r)
+		})
+	}
+	jobs = append(jobs, g.renderGroupVersionInfo, g.renderManaged)
+	return runJobs(g.GetConfig().GetJobs(), jobs)
 }
 
-func (g *Generator) Render() error {
+// getRenderResources returns the resources that are rendered, the full resource map
+// is rendered as a single resource from the root
+func (g *Generator) getRenderResources() []*resource.Resource {
 	if g.GetConfig().GetResourceMapAll() {
-		// Render the data
-		//for _, r := range g.GetActualResources()[:0] {
-		r := g.GetActualResources()[0]
-		fmt.Printf("Resource: %s\n", r.GetResourcePath())
-		fmt.Printf("Render Resource: %s\n", r.GetResourceNameWithPrefix(g.GetConfig().GetPrefix()))
-		fmt.Printf("Render Resource path: %s\n", yparser.GnmiPath2XPath(r.GetActualGnmiFullPathWithKeys(), true))
-		renderContainers(r.RootContainer)
-		//}
-	} else {
-		// Render the data
-		for _, r := range g.GetActualResources()[1:] {
-			//fmt.Printf("Resource: %s\n", r.GetResourcePath())
-			//fmt.Printf("Render Resource: %s\n", r.GetResourceNameWithPrefix(g.GetConfig().GetPrefix()))
-			//fmt.Printf("Render Resource path: %s\n", yparser.GnmiPath2XPath(r.GetActualGnmiFullPathWithKeys(), true))
-			renderContainers(r.RootContainer)
-		}
+		return g.GetActualResources()[:1]
 	}
-	//g.RenderSchemaMethods()
+	return g.GetActualResources()[1:]
+}
 
-	/*
-		for _, c := range r.ContainerList {
-			fmt.Printf("Render Container: HasState: %t, name: %s\n", c.HasState, c.GetFullName())
-			for _, e := range c.GetEntries() {
-				fmt.Printf("  Render Container Entry: state: %t, name: %s\n", e.ReadOnly, e.Name)
-			}
+// checkTypeNames returns an error when the go types of the resources collide, the go structs
+// of the containers are named after the container path within the resource, hence
+// resources that have a container with the same path render the same struct in the
+// api package
+func (g *Generator) checkTypeNames() error {
+	var errs Errors
+	types := map[string]string{}
+	for _, r := range g.getRenderResources() {
+		if r.RootContainer == nil {
+			continue
 		}
-	*/
-	//r.AssignFileName(g.GetConfig().GetPrefix(), "_types.go")
-	/*
-		if err := r.CreateFile(g.GetConfig().GetOutputDir(), "api", g.GetConfig().GetVersion()); err != nil {
-			return err
+		path := yparser.GnmiPath2XPath(r.GetAbsolutePath(), false)
+		resourceName := r.GetResourceNameWithPrefix(g.GetConfig().GetPrefix())
+		names := []string{resourceName, resourceName + "List", resourceName + "Parameters",
+			resourceName + "Observation", resourceName + "Spec", resourceName + "Status"}
+		for _, c := range g.getResourceContainers(r.RootContainer) {
+			names = append(names, strcase.UpperCamelCase(c.GetFullName()))
 		}
-		if err := g.WriteResourceHeader(r); err != nil {
-			g.log.Debug("Write resource header error", "error", err)
-			return err
-		}
-
-		/*
-		for _, c := range r.ContainerList {
-			if err := g.WriteResourceContainers(r, c); err != nil {
-				g.log.Debug("Write resource container error", "error", err)
-				return err
+		for _, name := range names {
+			if other, ok := types[name]; ok {
+				errs = appendError(errs, errors.Errorf("%s %s: resources %s and %s", errDuplicateTypeName, name, other, path))
+				continue
 			}
-
-
+			types[name] = path
 		}
-	*/
+	}
+	return errs.ErrorOrNil()
+}
 
-	/*
-		if err := g.WriteResourceEnd(r); err != nil {
-			g.log.Debug("Write resource end error", "error", err)
+// renderResource writes the kubernetes api types of a resource to
+// <outputDir>/apis/<version>/<prefix>_<resource>_types.go
+func (g *Generator) renderResource(r *resource.Resource) error {
+	if r.RootContainer == nil {
+		return errors.Errorf("%s: %s", errResourceNotFound, yparser.GnmiPath2XPath(r.GetAbsolutePath(), false))
+	}
+	dir := filepath.Join(g.GetConfig().GetOutputDir(), "apis", g.GetConfig().GetVersion())
+	buf := new(bytes.Buffer)
+	if err := g.WriteResourceHeader(buf, r); err != nil {
+		g.log.Debug("Write resource header error", "error", err)
+		return err
+	}
+	for _, c := range g.getResourceContainers(r.RootContainer) {
+		if err := g.WriteResourceContainer(buf, c); err != nil {
+			g.log.Debug("Write resource container error", "error", err)
 			return err
 		}
-	*/
+	}
+	if err := g.WriteResourceEnd(buf, r); err != nil {
+		g.log.Debug("Write resource end error", "error", err)
+		return err
+	}
+	b, err := formatSource(buf.Bytes())
+	if err != nil {
+		return errors.Wrapf(err, "%s, templates resourceHeader.tmpl, resourceContainer.tmpl and resourceEnd.tmpl, resource %s",
+			errFormatSource, yparser.GnmiPath2XPath(r.GetAbsolutePath(), false))
+	}
+	return g.writeFile(renderKindResources, filepath.Join(dir, g.getResourceFileName(r)), b)
+}
 
-	// EXPERIMENTAL
-	/*
-		if err := g.WriteResourceLocalLeafRef(r); err != nil {
-			g.log.Debug("Write resource local leafRef error", "error", err)
-			return err
-		}
-		if err := g.WriteResourceExternalLeafRef(r); err != nil {
-			g.log.Debug("Write resource external leafRef error", "error", err)
-			return err
-		}
-	*/
+// renderGroupVersionInfo writes the group version, the scheme builder and the registration
+// of the resources to <outputDir>/apis/<version>/groupversion_info.go
+func (g *Generator) renderGroupVersionInfo() error {
+	s := struct {
+		Version   string
+		ApiGroup  string
+		Resources []string
+	}{
+		Version:   g.GetConfig().GetVersion(),
+		ApiGroup:  g.GetConfig().GetApiGroup(),
+		Resources: make([]string, 0),
+	}
+	for _, r := range g.getRenderResources() {
+		s.Resources = append(s.Resources, r.GetResourceNameWithPrefix(g.GetConfig().GetPrefix()))
+	}
 
-	/*
-		if err := r.CloseFile(); err != nil {
-			return err
-		}
-	*/
+	buf := new(bytes.Buffer)
+	if err := g.getTemplate().ExecuteTemplate(buf, "groupversion_info"+".tmpl", s); err != nil {
+		return err
+	}
+	b, err := formatSource(buf.Bytes())
+	if err != nil {
+		return errors.Wrapf(err, "%s, template groupversion_info.tmpl", errFormatSource)
+	}
+	dir := filepath.Join(g.GetConfig().GetOutputDir(), "apis", g.GetConfig().GetVersion())
+	return g.writeFile(renderKindResources, filepath.Join(dir, groupVersionInfoFileName), b)
+}
 
-	return nil
+// renderManaged writes the methods that implement resource.Managed and resource.ManagedList
+// for the resources to <outputDir>/apis/<version>/zz_generated.managed.go
+func (g *Generator) renderManaged() error {
+	s := struct {
+		Version   string
+		Resources []string
+	}{
+		Version:   g.GetConfig().GetVersion(),
+		Resources: make([]string, 0),
+	}
+	for _, r := range g.getRenderResources() {
+		s.Resources = append(s.Resources, r.GetResourceNameWithPrefix(g.GetConfig().GetPrefix()))
+	}
+
+	buf := new(bytes.Buffer)
+	if err := g.getTemplate().ExecuteTemplate(buf, "managed"+".tmpl", s); err != nil {
+		return err
+	}
+	b, err := formatSource(buf.Bytes())
+	if err != nil {
+		return errors.Wrapf(err, "%s, template managed.tmpl", errFormatSource)
+	}
+	dir := filepath.Join(g.GetConfig().GetOutputDir(), "apis", g.GetConfig().GetVersion())
+	return g.writeFile(renderKindResources, filepath.Join(dir, managedFileName), b)
+}
+
+// getResourceFileName returns the file name of the api types of a resource
+func (g *Generator) getResourceFileName(r *resource.Resource) string {
+	return strcase.SnakeCase(g.GetConfig().GetPrefix()+"-"+r.GetAbsoluteName()) + "_types.go"
 }
 
-/*
 // WriteResourceHeader
-func (g *Generator) WriteResourceHeader(r *resource.Resource) error {
+func (g *Generator) WriteResourceHeader(w io.Writer, r *resource.Resource) error {
 	s := struct {
 		Version                string
 		ApiGroup               string
 		ResourceLastElement    string
 		ResourceNameWithPrefix string
-		ResourceTest1          *gnmi.Path
-		ResourceTest2          *gnmi.Path
-		ResourceTest3          *gnmi.Path
 	}{
 		Version:                g.GetConfig().GetVersion(),
 		ApiGroup:               g.GetConfig().GetApiGroup(),
@@ -139,54 +198,53 @@ func (g *Generator) WriteResourceHeader(r *resource.Resource) error {
 		ResourceNameWithPrefix: r.GetResourceNameWithPrefix(g.GetConfig().GetPrefix()),
 	}
 
-	if err := g.getTemplate().ExecuteTemplate(r.ResFile, "resourceHeader"+".tmpl", s); err != nil {
+	if err := g.getTemplate().ExecuteTemplate(w, "resourceHeader"+".tmpl", s); err != nil {
 		return err
 	}
 	return nil
 }
-*/
 
-/*
-// WriteResourceContainers
-func (g *Generator) WriteResourceContainers(r *resource.Resource, c *container.Container) error {
+// WriteResourceContainer
+func (g *Generator) WriteResourceContainer(w io.Writer, c *container.Container) error {
 	s := struct {
-		Name    string
-		Entries []*container.Entry
+		Name          string
+		Entries       []*container.Entry
+		ChoiceEntries map[string]bool   // the entries that belong to a case of a choice
+		LeafLists     map[string]string // the go types of the values of the leaf-lists
 	}{
-		Name:    c.GetFullName(),
-		Entries: c.Entries,
+		Name:          c.GetFullName(),
+		Entries:       c.GetEntries(),
+		ChoiceEntries: g.getChoiceEntries(c),
+		LeafLists:     g.getLeafListTypes(c),
 	}
 
-	if err := g.getTemplate().ExecuteTemplate(r.ResFile, "resourceContainer"+".tmpl", s); err != nil {
+	if err := g.getTemplate().ExecuteTemplate(w, "resourceContainer"+".tmpl", s); err != nil {
 		return err
 	}
 	return nil
 }
-*/
-/*
-func (g *Generator) WriteResourceEnd(r *resource.Resource) error {
-
-	r.GetHierarchicalElements()
 
+// WriteResourceEnd
+func (g *Generator) WriteResourceEnd(w io.Writer, r *resource.Resource) error {
 	s := struct {
 		Prefix                 string
 		ResourceLastElement    string
 		ResourceName           string
 		ResourceNameWithPrefix string
-		HElements              []*resource.HeInfo
+		HElements              []*HeInfo
 	}{
-		Prefix:                 g.GetConfig().GetPrefix(),
-		ResourceLastElement:    strcase.UpperCamelCase(r.ResourceLastElement()),
+		Prefix: g.GetConfig().GetPrefix(),
+		// the root struct of the resource is named after the full name of the root container
+		ResourceLastElement:    strcase.UpperCamelCase(r.RootContainer.GetFullName()),
 		ResourceName:           r.GetResourceNameWithPrefix(""),
 		ResourceNameWithPrefix: r.GetResourceNameWithPrefix(g.GetConfig().GetPrefix()),
-		HElements:              r.GetHierarchicalElements(),
+		HElements:              getHierarchicalElements(r),
 	}
-	if err := g.getTemplate().ExecuteTemplate(r.ResFile, "resourceEnd"+".tmpl", s); err != nil {
+	if err := g.getTemplate().ExecuteTemplate(w, "resourceEnd"+".tmpl", s); err != nil {
 		return err
 	}
 	return nil
 }
-*/
 
 /*
 func (g *Generator) WriteResourceLocalLeafRef(r *resource.Resource) error {
@@ -226,37 +284,40 @@ func (g *Generator) WriteResourceExternalLeafRef(r *resource.Resource) error {
 */
 
 func (g *Generator) RenderSchema() error {
-	if err := g.renderSchema(g.GetResources()[0].RootContainer); err != nil {
-		return err
+	g.startRender(renderKindSchema)
+	jobs := make([]func() error, 0)
+	for _, c := range getContainers(g.GetResources()[0].RootContainer) {
+		c := c
+		jobs = append(jobs, func() error {
+			return g.renderSchema(c)
+		})
 	}
+	return runJobs(g.GetConfig().GetJobs(), jobs)
+}
 
-	return nil
+// getContainers returns the container and its children recursively
+func getContainers(c *container.Container) []*container.Container {
+	cs := []*container.Container{c}
+	for _, c := range c.Children {
+		cs = append(cs, getContainers(c)...)
+	}
+	return cs
 }
 
+// renderSchema writes the schema of the container to <outputDir>/yangschema/<container>.go
 func (g *Generator) renderSchema(c *container.Container) error {
 	//fmt.Printf("Container FullName %s\n", c.GetFullNameWithRoot())
 
-	f, err := os.Create(filepath.Join(g.GetConfig().GetOutputDir(), "yangschema", c.GetFullNameWithRoot()+".go"))
-	if err != nil {
-		return err
-	}
-
-	if err := g.WriteContainer(f, c); err != nil {
+	buf := new(bytes.Buffer)
+	if err := g.WriteContainer(buf, c); err != nil {
 		g.log.Debug("Write container error", "error", err)
 		return err
 	}
-
-	if err := f.Close(); err != nil {
-		return err
-	}
-
-	for _, c := range c.Children {
-		if err := g.renderSchema(c); err != nil {
-			return err
-		}
-
+	b, err := formatSource(buf.Bytes())
+	if err != nil {
+		return errors.Wrapf(err, "%s, template container.tmpl, container %s", errFormatSource, c.GetFullNameWithRoot())
 	}
-	return nil
+	return g.writeFile(renderKindSchema, filepath.Join(g.GetConfig().GetOutputDir(), "yangschema", c.GetFullNameWithRoot()+".go"), b)
 }
 
 func (g *Generator) RenderSchemaMethods() error {
@@ -274,7 +335,7 @@ func (g *Generator) RenderSchemaMethods() error {
 	return nil
 }
 
-func (g *Generator) WriteContainer(f *os.File, c *container.Container) error {
+func (g *Generator) WriteContainer(w io.Writer, c *container.Container) error {
 	s := struct {
 		Name             string
 		Module           string
@@ -296,10 +357,10 @@ func (g *Generator) WriteContainer(f *os.File, c *container.Container) error {
 		Children:         c.GetChildrenNames(),
 		ResourceBoundary: c.GetResourceBoundary(),
 		LeafRefs:         c.GetLeafRefs(),
-		Defaults:         c.GetDefaults(),
+		Defaults:         g.getContainerDefaults(c),
 	}
 	//g.log.Debug("External leafrefs", "external leafref", r.LocalLeafRefs)
-	if err := g.getTemplate().ExecuteTemplate(f, "container.tmpl", s); err != nil {
+	if err := g.getTemplate().ExecuteTemplate(w, "container.tmpl", s); err != nil {
 		return err
 	}
 	return nil
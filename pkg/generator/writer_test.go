@@ -0,0 +1,118 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yndd/ndd-runtime/pkg/logging"
+	"github.com/yndd/ndd-ygen/pkg/fsys"
+)
+
+// newSampleGenerator returns a generator that processed the sample yang models of the
+// testdata with the resource map
+func newSampleGenerator(t *testing.T, resourceMap string) *Generator {
+	t.Helper()
+	inputFS := fsys.NewMemFS()
+	names, err := filepath.Glob(filepath.Join(testdataDir, "yang", "*.yang"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range names {
+		b, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := inputFS.WriteFile(filepath.Join("yang", filepath.Base(name)), b, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := inputFS.WriteFile("map.yaml", []byte(resourceMap), 0644); err != nil {
+		t.Fatal(err)
+	}
+	g, err := NewGenerator(
+		WithInputFS(inputFS),
+		WithOutputFS(fsys.NewMemFS()),
+		WithLogging(logging.NewNopLogger()),
+		WithYangModuleDirs([]string{"yang"}),
+		WithResourceMapInputFile("map.yaml"),
+		WithOutputDir(testOutputDir),
+		WithVersion("v1alpha1"),
+		WithAPIGroup("sample.ndd.yndd.io"),
+		WithPrefix("sample"),
+		WithGoModule(testGoModule),
+		WithLocalRender(true),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Run(); err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestDuplicateTypeNames(t *testing.T) {
+	// the root containers of both resources render the Interface struct
+	g := newSampleGenerator(t, `path:
+  /sample-interfaces/interfaces/interface:
+    excludes:
+      - /subinterface
+  /sample-network-instance/network-instances/network-instance:
+    excludes:
+      - /protocols
+    hierarchy:
+      /interface:
+`)
+	want := errDuplicateTypeName + " Interface: resources /sample-interfaces/interfaces/interface and /sample-network-instance/network-instances/network-instance/interface"
+	for name, render := range map[string]func() error{
+		"Render":         g.Render,
+		"RenderDeepCopy": g.RenderDeepCopy,
+		"RenderRFC7951":  g.RenderRFC7951,
+	} {
+		if err := render(); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: expected error %q, got %v", name, want, err)
+		}
+	}
+}
+
+func TestRenderAPIPackage(t *testing.T) {
+	// the api types and the deepcopy functions that are rendered by default compile
+	// on their own, the scheme registration of the api types requires the deepcopy functions
+	g := newSampleGenerator(t, `path:
+  /sample-interfaces/interfaces/interface:
+`)
+	if err := g.Render(); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.RenderDeepCopy(); err != nil {
+		t.Fatal(err)
+	}
+	outputFS := g.GetOutputFS().(*fsys.MemFS)
+	files := map[string][]byte{}
+	for _, name := range outputFS.GetFileNames() {
+		b, err := outputFS.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[name] = b
+	}
+	runGoModule(t, files, []string{"build", "./..."}, []string{"vet", "./..."})
+}
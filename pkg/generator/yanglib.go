@@ -0,0 +1,418 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/pkg/errors"
+	"github.com/yndd/ndd-ygen/pkg/fsys"
+)
+
+const (
+	errYangLibraryUnmarshal    = "cannot unmarshal yang library"
+	errYangLibraryEmpty        = "yang library has no yang-library or modules-state data"
+	errYangLibraryModule       = "cannot find yang library module in the yang module and import dirs"
+	errYangLibrarySingleModule = "expected a single module or submodule"
+)
+
+// yangLibrary are the modules of an RFC 8525 yang library document, the module sets
+// of the document are merged. The modules-state data of RFC 7895 is supported as well.
+type yangLibrary struct {
+	// the implemented modules
+	modules []*yangLibraryModule
+	// the modules that are only used for imports
+	importOnlyModules []*yangLibraryModule
+}
+
+type yangLibraryDocument struct {
+	YangLibrary  *yangLibraryData  `json:"ietf-yang-library:yang-library"`
+	ModulesState *modulesStateData `json:"ietf-yang-library:modules-state"`
+}
+
+type yangLibraryData struct {
+	ModuleSets []*yangLibraryModuleSet `json:"module-set" xml:"module-set"`
+}
+
+type yangLibraryModuleSet struct {
+	Name              string               `json:"name" xml:"name"`
+	Modules           []*yangLibraryModule `json:"module" xml:"module"`
+	ImportOnlyModules []*yangLibraryModule `json:"import-only-module" xml:"import-only-module"`
+}
+
+type yangLibraryModule struct {
+	Name       string                  `json:"name" xml:"name"`
+	Revision   string                  `json:"revision" xml:"revision"`
+	Features   []string                `json:"feature" xml:"feature"`
+	Deviations []string                `json:"deviation" xml:"deviation"`
+	Submodules []*yangLibrarySubmodule `json:"submodule" xml:"submodule"`
+}
+
+type yangLibrarySubmodule struct {
+	Name     string `json:"name" xml:"name"`
+	Revision string `json:"revision" xml:"revision"`
+}
+
+// modulesStateData is the deprecated modules-state data of RFC 7895
+type modulesStateData struct {
+	Modules []*modulesStateModule `json:"module" xml:"module"`
+}
+
+type modulesStateModule struct {
+	Name            string                  `json:"name" xml:"name"`
+	Revision        string                  `json:"revision" xml:"revision"`
+	Features        []string                `json:"feature" xml:"feature"`
+	Deviations      []*yangLibrarySubmodule `json:"deviation" xml:"deviation"`
+	ConformanceType string                  `json:"conformance-type" xml:"conformance-type"`
+	Submodules      []*yangLibrarySubmodule `json:"submodule" xml:"submodule"`
+}
+
+// parseYangLibrary parses a yang library document, the encoding is XML when
+// the document starts with a '<' and JSON otherwise
+func parseYangLibrary(b []byte) (*yangLibrary, error) {
+	doc := &yangLibraryDocument{}
+	if bytes.HasPrefix(bytes.TrimSpace(b), []byte("<")) {
+		if err := unmarshalYangLibraryXML(b, doc); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := json.Unmarshal(b, doc); err != nil {
+			return nil, err
+		}
+	}
+
+	lib := &yangLibrary{
+		modules:           make([]*yangLibraryModule, 0),
+		importOnlyModules: make([]*yangLibraryModule, 0),
+	}
+	switch {
+	case doc.YangLibrary != nil:
+		for _, ms := range doc.YangLibrary.ModuleSets {
+			lib.modules = append(lib.modules, ms.Modules...)
+			lib.importOnlyModules = append(lib.importOnlyModules, ms.ImportOnlyModules...)
+		}
+	case doc.ModulesState != nil:
+		for _, m := range doc.ModulesState.Modules {
+			lm := &yangLibraryModule{
+				Name:       m.Name,
+				Revision:   m.Revision,
+				Features:   m.Features,
+				Submodules: m.Submodules,
+			}
+			for _, d := range m.Deviations {
+				lm.Deviations = append(lm.Deviations, d.Name)
+			}
+			if m.ConformanceType == "import" {
+				lib.importOnlyModules = append(lib.importOnlyModules, lm)
+				continue
+			}
+			lib.modules = append(lib.modules, lm)
+		}
+	default:
+		return nil, errors.New(errYangLibraryEmpty)
+	}
+	return lib, nil
+}
+
+// unmarshalYangLibraryXML decodes the first yang-library or modules-state element,
+// such that the data can be wrapped in e.g. a netconf data or rpc-reply element
+func unmarshalYangLibraryXML(b []byte, doc *yangLibraryDocument) error {
+	d := xml.NewDecoder(bytes.NewReader(b))
+	for {
+		t, err := d.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		se, ok := t.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch se.Name.Local {
+		case "yang-library":
+			doc.YangLibrary = &yangLibraryData{}
+			return d.DecodeElement(doc.YangLibrary, &se)
+		case "modules-state":
+			doc.ModulesState = &modulesStateData{}
+			return d.DecodeElement(doc.ModulesState, &se)
+		}
+	}
+}
+
+// getFeatures returns the enabled features of the implemented modules as module:feature
+func (lib *yangLibrary) getFeatures() []string {
+	features := make([]string, 0)
+	for _, m := range lib.modules {
+		for _, f := range m.Features {
+			features = append(features, m.Name+":"+f)
+		}
+	}
+	return features
+}
+
+// getDeviationModules returns the names of the modules that deviate the implemented modules
+func (lib *yangLibrary) getDeviationModules() map[string]bool {
+	deviations := make(map[string]bool)
+	for _, m := range lib.modules {
+		for _, d := range m.Deviations {
+			deviations[d] = true
+		}
+	}
+	return deviations
+}
+
+// readYangLibrary reads the yang library document from the input filesystem
+func (g *Generator) readYangLibrary(file string) (*yangLibrary, error) {
+	b, err := g.GetInputFS().ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	lib, err := parseYangLibrary(b)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s %s", errYangLibraryUnmarshal, file)
+	}
+	return lib, nil
+}
+
+// yangModuleFile is a yang file in the yang module and import dirs
+type yangModuleFile struct {
+	*moduleHeader
+	source *yangFiles
+	file   string
+}
+
+// getYangLibraryFiles returns the files of the modules and submodules in the yang library.
+// The implemented modules are returned as modules, the import-only modules, the deviation
+// modules and the submodules are returned as imports. The modules are searched in the
+// yang module and import dirs, when the yang library does not list the revision of a
+// module the latest revision is used.
+func (g *Generator) getYangLibraryFiles(lib *yangLibrary) ([]*yangFiles, []*yangFiles, error) {
+	paths := append(append([]string{}, g.GetConfig().GetYangModuleDirs()...), g.GetConfig().GetYangImportDirs()...)
+	index, err := g.getYangModuleFiles(paths)
+	if err != nil {
+		return nil, nil, err
+	}
+	find := func(kind, name, revision string) (*yangModuleFile, error) {
+		var found *yangModuleFile
+		for _, mf := range index[name] {
+			if mf.kind != kind || (revision != "" && mf.revision != revision) {
+				continue
+			}
+			if found == nil || mf.revision > found.revision {
+				found = mf
+			}
+		}
+		if found == nil {
+			if revision != "" {
+				name += "@" + revision
+			}
+			return nil, errors.Errorf("%s: %s %s", errYangLibraryModule, kind, name)
+		}
+		return found, nil
+	}
+
+	deviations := lib.getDeviationModules()
+	modules := make([]*yangModuleFile, 0)
+	imports := make([]*yangModuleFile, 0)
+	for _, m := range append(append([]*yangLibraryModule{}, lib.modules...), lib.importOnlyModules...) {
+		mf, err := find("module", m.Name, m.Revision)
+		if err != nil {
+			return nil, nil, err
+		}
+		if deviations[m.Name] || !lib.isImplemented(m) {
+			imports = append(imports, mf)
+		} else {
+			modules = append(modules, mf)
+		}
+		for _, sm := range m.Submodules {
+			smf, err := find("submodule", sm.Name, sm.Revision)
+			if err != nil {
+				return nil, nil, err
+			}
+			imports = append(imports, smf)
+		}
+	}
+	return groupYangModuleFiles(modules), groupYangModuleFiles(imports), nil
+}
+
+func (lib *yangLibrary) isImplemented(m *yangLibraryModule) bool {
+	for _, im := range lib.modules {
+		if im == m {
+			return true
+		}
+	}
+	return false
+}
+
+// groupYangModuleFiles groups the files by the filesystem they are read from
+func groupYangModuleFiles(mfs []*yangModuleFile) []*yangFiles {
+	groups := make([]*yangFiles, 0)
+	sources := make(map[*yangFiles]*yangFiles)
+	for _, mf := range mfs {
+		yf, ok := sources[mf.source]
+		if !ok {
+			yf = &yangFiles{fs: mf.source.fs, archive: mf.source.archive}
+			sources[mf.source] = yf
+			groups = append(groups, yf)
+		}
+		yf.files = append(yf.files, mf.file)
+	}
+	return groups
+}
+
+// getYangModuleFiles returns the yang files in the supplied directories, files or
+// archives, keyed by the name of the module or submodule. The directories are
+// searched recursively, the files that cannot be parsed are skipped.
+func (g *Generator) getYangModuleFiles(paths []string) (map[string][]*yangModuleFile, error) {
+	sources := make([]*yangFiles, 0)
+	for _, d := range paths {
+		if _, _, ok := fsys.SplitArchivePath(d); ok {
+			modules, imports, err := g.getYangFiles([]string{d})
+			if err != nil {
+				return nil, err
+			}
+			sources = append(append(sources, modules...), imports...)
+			continue
+		}
+		files, err := findYangFiles(g.GetInputFS(), d)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, &yangFiles{fs: g.GetInputFS(), files: files})
+	}
+
+	mfs := make([]*yangModuleFile, 0)
+	for _, yf := range sources {
+		for _, file := range yf.files {
+			if strings.HasSuffix(file, ".yang") {
+				mfs = append(mfs, &yangModuleFile{source: yf, file: file})
+			}
+		}
+	}
+	jobs := make([]func() error, 0, len(mfs))
+	for _, mf := range mfs {
+		mf := mf
+		jobs = append(jobs, func() error {
+			data, err := mf.source.fs.ReadFile(mf.file)
+			if err != nil {
+				return err
+			}
+			// a file that is not a single module or submodule is not selected by the
+			// yang library, this way stray files in the search dirs do not fail the run
+			hs, err := getModuleHeaders(string(data), mf.file)
+			if err != nil {
+				g.log.Info("skip yang file", "file", mf.file, "error", err.Error())
+				return nil
+			}
+			if len(hs) != 1 {
+				g.log.Info("skip yang file", "file", mf.file, "error", errYangLibrarySingleModule)
+				return nil
+			}
+			mf.moduleHeader = hs[0]
+			return nil
+		})
+	}
+	if err := runJobs(g.GetConfig().GetJobs(), jobs); err != nil {
+		return nil, err
+	}
+
+	index := make(map[string][]*yangModuleFile)
+	for _, mf := range mfs {
+		if mf.moduleHeader == nil {
+			continue
+		}
+		index[mf.name] = append(index[mf.name], mf)
+	}
+	return index, nil
+}
+
+// findYangFiles returns the .yang files in the directory and its subdirectories
+// sorted by name, or the file itself when the path is a file
+func findYangFiles(f fsys.FS, path string) ([]string, error) {
+	fi, err := f.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !fi.IsDir() {
+		return []string{path}, nil
+	}
+	fis, err := f.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]string, 0)
+	for _, fi := range fis {
+		p := filepath.Join(path, fi.Name())
+		if fi.IsDir() {
+			sub, err := findYangFiles(f, p)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, sub...)
+			continue
+		}
+		if strings.HasSuffix(fi.Name(), ".yang") {
+			files = append(files, p)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// moduleHeader is the name and latest revision of a module or submodule
+type moduleHeader struct {
+	kind     string
+	name     string
+	revision string
+}
+
+// fullName returns the name of the module as used by goyang to register the module
+func (h *moduleHeader) fullName() string {
+	if h.revision == "" {
+		return h.name
+	}
+	return h.name + "@" + h.revision
+}
+
+// getModuleHeaders parses the yang data and returns the headers of the modules and
+// submodules without processing the modules
+func getModuleHeaders(data, file string) ([]*moduleHeader, error) {
+	ss, err := yang.Parse(data, file)
+	if err != nil {
+		return nil, err
+	}
+	hs := make([]*moduleHeader, 0, len(ss))
+	for _, s := range ss {
+		h := &moduleHeader{kind: s.Keyword, name: s.Argument}
+		for _, sub := range s.SubStatements() {
+			if sub.Keyword == "revision" && sub.Argument > h.revision {
+				h.revision = sub.Argument
+			}
+		}
+		hs = append(hs, h)
+	}
+	return hs, nil
+}
@@ -0,0 +1,336 @@
+/*
+Copyright 2020 Wim Henderickx.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yndd/ndd-runtime/pkg/logging"
+	"github.com/yndd/ndd-ygen/pkg/fsys"
+)
+
+func TestParseYangLibrary(t *testing.T) {
+	cases := map[string]struct {
+		doc            string
+		wantModules    []string
+		wantImportOnly []string
+		wantFeatures   []string
+		wantDeviations map[string]bool
+	}{
+		"YangLibraryJSON": {
+			doc: `{
+  "ietf-yang-library:yang-library": {
+    "module-set": [
+      {
+        "name": "config",
+        "module": [
+          {"name": "test-interfaces", "revision": "2021-01-01", "feature": ["lag", "qos"], "deviation": ["test-deviations"]},
+          {"name": "test-deviations", "revision": "2021-02-01"}
+        ],
+        "import-only-module": [
+          {"name": "test-types", "revision": "2020-01-01"}
+        ]
+      }
+    ],
+    "content-id": "1"
+  }
+}`,
+			wantModules:    []string{"test-interfaces@2021-01-01", "test-deviations@2021-02-01"},
+			wantImportOnly: []string{"test-types@2020-01-01"},
+			wantFeatures:   []string{"test-interfaces:lag", "test-interfaces:qos"},
+			wantDeviations: map[string]bool{"test-deviations": true},
+		},
+		"YangLibraryXML": {
+			doc: `<yang-library xmlns="urn:ietf:params:xml:ns:yang:ietf-yang-library">
+  <module-set>
+    <name>config</name>
+    <module>
+      <name>test-interfaces</name>
+      <feature>lag</feature>
+    </module>
+    <import-only-module>
+      <name>test-types</name>
+      <revision>2020-01-01</revision>
+    </import-only-module>
+  </module-set>
+</yang-library>`,
+			wantModules:    []string{"test-interfaces"},
+			wantImportOnly: []string{"test-types@2020-01-01"},
+			wantFeatures:   []string{"test-interfaces:lag"},
+			wantDeviations: map[string]bool{},
+		},
+		"ModulesStateXML": {
+			doc: `<rpc-reply xmlns="urn:ietf:params:xml:ns:netconf:base:1.0">
+  <data>
+    <modules-state xmlns="urn:ietf:params:xml:ns:yang:ietf-yang-library">
+      <module>
+        <name>test-interfaces</name>
+        <revision>2021-01-01</revision>
+        <deviation><name>test-deviations</name><revision>2021-02-01</revision></deviation>
+        <conformance-type>implement</conformance-type>
+      </module>
+      <module>
+        <name>test-types</name>
+        <revision>2020-01-01</revision>
+        <conformance-type>import</conformance-type>
+      </module>
+    </modules-state>
+  </data>
+</rpc-reply>`,
+			wantModules:    []string{"test-interfaces@2021-01-01"},
+			wantImportOnly: []string{"test-types@2020-01-01"},
+			wantFeatures:   []string{},
+			wantDeviations: map[string]bool{"test-deviations": true},
+		},
+	}
+
+	fullNames := func(ms []*yangLibraryModule) []string {
+		names := make([]string, 0, len(ms))
+		for _, m := range ms {
+			h := &moduleHeader{name: m.Name, revision: m.Revision}
+			names = append(names, h.fullName())
+		}
+		return names
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			lib, err := parseYangLibrary([]byte(tc.doc))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := fullNames(lib.modules); !reflect.DeepEqual(got, tc.wantModules) {
+				t.Errorf("modules: got %v, want %v", got, tc.wantModules)
+			}
+			if got := fullNames(lib.importOnlyModules); !reflect.DeepEqual(got, tc.wantImportOnly) {
+				t.Errorf("import-only modules: got %v, want %v", got, tc.wantImportOnly)
+			}
+			if got := lib.getFeatures(); !reflect.DeepEqual(got, tc.wantFeatures) {
+				t.Errorf("features: got %v, want %v", got, tc.wantFeatures)
+			}
+			if got := lib.getDeviationModules(); !reflect.DeepEqual(got, tc.wantDeviations) {
+				t.Errorf("deviation modules: got %v, want %v", got, tc.wantDeviations)
+			}
+		})
+	}
+
+	if _, err := parseYangLibrary([]byte(`{"ietf-interfaces:interfaces": {}}`)); err == nil {
+		t.Error("expected an error for a document without yang library data")
+	}
+}
+
+const testLibYang = `module test-lib {
+  namespace "urn:test:lib";
+  prefix tl;
+  import test-lib-types { prefix tlt; }
+  include test-lib-sub;
+  revision 2021-01-01;
+  revision 2020-01-01;
+
+  container system {
+    leaf name {
+      type tlt:name;
+    }
+    leaf description {
+      type string;
+    }
+  }
+}
+`
+
+const testLibOldYang = `module test-lib {
+  namespace "urn:test:lib";
+  prefix tl;
+  revision 2020-01-01;
+
+  container system {
+    leaf name {
+      type string;
+    }
+  }
+}
+`
+
+const testLibSubYang = `submodule test-lib-sub {
+  belongs-to test-lib { prefix tl; }
+
+  container extra {
+    leaf x {
+      type string;
+    }
+  }
+}
+`
+
+const testLibTypesYang = `module test-lib-types {
+  namespace "urn:test:lib:types";
+  prefix tlt;
+  revision 2020-06-01;
+
+  typedef name {
+    type string {
+      length "1..32";
+    }
+  }
+}
+`
+
+const testLibDevYang = `module test-lib-dev {
+  namespace "urn:test:lib:dev";
+  prefix tld;
+  import test-lib { prefix tl; }
+
+  deviation /tl:system/tl:description {
+    deviate not-supported;
+  }
+}
+`
+
+const testLibDoc = `{
+  "ietf-yang-library:modules-state": {
+    "module": [
+      {"name": "test-lib", "revision": "%s", "conformance-type": "implement",
+       "deviation": [{"name": "test-lib-dev"}], "submodule": [{"name": "test-lib-sub"}]},
+      {"name": "test-lib-dev", "conformance-type": "implement"},
+      {"name": "test-lib-types", "conformance-type": "import"}
+    ]
+  }
+}`
+
+// yangFileNames returns the names of the files of the yang files
+func yangFileNames(yfs []*yangFiles) []string {
+	names := make([]string, 0)
+	for _, yf := range yfs {
+		names = append(names, yf.files...)
+	}
+	return names
+}
+
+func TestYangLibraryFiles(t *testing.T) {
+	inputFS := fsys.NewMemFS()
+	for name, data := range map[string]string{
+		"yang/test-lib.yang":          testLibYang,
+		"yang/old/test-lib.yang":      testLibOldYang,
+		"yang/sub/test-lib-sub.yang":  testLibSubYang,
+		"common/test-lib-types.yang":  testLibTypesYang,
+		"common/test-lib-dev.yang":    testLibDevYang,
+		"yang/stray.yang":             "this is not { yang",
+		"yang/old/broken-module.yang": "module broken {\n  namespace \"urn:broken\";\n",
+		"yang/library.json":           strings.Replace(testLibDoc, "%s", "2021-01-01", 1),
+		"map.yaml":                    "path:\n  /test-lib/system:\n",
+	} {
+		if err := inputFS.WriteFile(name, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the unparsable files in the module dirs are skipped
+	outputFS := fsys.NewMemFS()
+	g, err := NewGenerator(
+		WithInputFS(inputFS),
+		WithOutputFS(outputFS),
+		WithLogging(logging.NewNopLogger()),
+		WithYangModuleDirs([]string{"yang"}),
+		WithYangImportDirs([]string{"common"}),
+		WithYangLibrary("yang/library.json"),
+		WithResourceMapInputFile("map.yaml"),
+		WithOutputDir("out"),
+		WithVersion("v1alpha1"),
+		WithAPIGroup("test.ndd.yndd.io"),
+		WithPrefix("test"),
+		WithLocalRender(true),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Render(); err != nil {
+		t.Fatal(err)
+	}
+	b, err := outputFS.ReadFile("out/apis/v1alpha1/test_system_types.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), "Name *string") || strings.Contains(string(b), "Description") {
+		t.Errorf("expected the system of the selected revision with the deviation applied:\n%s", b)
+	}
+
+	cases := map[string]struct {
+		revision    string
+		wantModules []string
+		wantImports []string
+		wantErr     string
+	}{
+		"Revision": {
+			revision:    "2021-01-01",
+			wantModules: []string{"yang/test-lib.yang"},
+			wantImports: []string{"yang/sub/test-lib-sub.yang", "common/test-lib-dev.yang", "common/test-lib-types.yang"},
+		},
+		"OlderRevision": {
+			// the revision is the latest revision statement of the module
+			revision:    "2020-01-01",
+			wantModules: []string{"yang/old/test-lib.yang"},
+			wantImports: []string{"yang/sub/test-lib-sub.yang", "common/test-lib-dev.yang", "common/test-lib-types.yang"},
+		},
+		"LatestRevision": {
+			revision:    "",
+			wantModules: []string{"yang/test-lib.yang"},
+			wantImports: []string{"yang/sub/test-lib-sub.yang", "common/test-lib-dev.yang", "common/test-lib-types.yang"},
+		},
+		"UnknownRevision": {
+			revision: "2019-01-01",
+			wantErr:  errYangLibraryModule + ": module test-lib@2019-01-01",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			lib, err := parseYangLibrary([]byte(strings.Replace(testLibDoc, "%s", tc.revision, 1)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			modules, imports, err := g.getYangLibraryFiles(lib)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("got error %v, want %s", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := yangFileNames(modules), fromSlash(tc.wantModules); !reflect.DeepEqual(got, want) {
+				t.Errorf("modules: got %v, want %v", got, want)
+			}
+			if got, want := yangFileNames(imports), fromSlash(tc.wantImports); !reflect.DeepEqual(got, want) {
+				t.Errorf("imports: got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func fromSlash(names []string) []string {
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		result = append(result, filepath.FromSlash(name))
+	}
+	return result
+}
@@ -24,6 +24,7 @@ package templ
 
 import (
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -31,24 +32,67 @@ import (
 
 	"github.com/Masterminds/sprig"
 	"github.com/stoewer/go-strcase"
+	"github.com/yndd/ndd-ygen/pkg/fsys"
+	"github.com/yndd/ndd-ygen/templates"
 )
 
-func ParseTemplates(path string) (*template.Template, error) {
+// ParseTemplates parses the built-in templates; when path is not empty the templates
+// found in path are layered on top of the built-in templates, overriding them by name
+func ParseTemplates(f fsys.FS, path string) (*template.Template, error) {
 	templ := template.New("ndd").Funcs(templateHelperFunctions).Funcs(sprig.TxtFuncMap())
-	err := filepath.Walk(path, func(path string, info os.FileInfo, err error) error {
-		if strings.HasSuffix(path, ".tmpl") {
-			_, err = templ.ParseFiles(path)
+	if _, err := templ.ParseFS(templates.FS, "*.tmpl"); err != nil {
+		return nil, err
+	}
+	if path == "" {
+		return templ, nil
+	}
+	if err := parseTemplateDir(templ, f, path); err != nil {
+		return nil, err
+	}
+	return templ, nil
+}
+
+// parseTemplateDir parses the templates in the directory and its sub directories
+func parseTemplateDir(templ *template.Template, f fsys.FS, dir string) error {
+	fis, err := f.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, fi := range fis {
+		path := filepath.Join(dir, fi.Name())
+		switch {
+		case fi.IsDir():
+			if err := parseTemplateDir(templ, f, path); err != nil {
+				return err
+			}
+		case strings.HasSuffix(path, ".tmpl"):
+			b, err := f.ReadFile(path)
 			if err != nil {
 				return err
 			}
+			if _, err := templ.New(fi.Name()).Parse(string(b)); err != nil {
+				return err
+			}
 		}
-		return err
-	})
-	if err != nil {
-		return nil, err
 	}
+	return nil
+}
 
-	return templ, nil
+// ExportTemplates writes the built-in templates to the directory
+func ExportTemplates(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return fs.WalkDir(templates.FS, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".tmpl") {
+			return err
+		}
+		b, err := fs.ReadFile(templates.FS, path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(dir, path), b, 0644)
+	})
 }
 
 // templateHelperFunctions specifies a set of functions that are supplied as
@@ -17,13 +17,13 @@ limitations under the License.
 package utils
 
 import (
-	"os"
+	"github.com/yndd/ndd-ygen/pkg/fsys"
 )
 
 // FileExists function
-func FileExists(filename string) bool {
-	info, err := os.Stat(filename)
-	if os.IsNotExist(err) {
+func FileExists(f fsys.FS, filename string) bool {
+	info, err := f.Stat(filename)
+	if err != nil {
 		return false
 	}
 	return !info.IsDir()